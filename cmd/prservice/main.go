@@ -3,15 +3,26 @@ package main
 import (
 	"context"
 	"log"
+	"log/slog"
 	"net/http"
+	"net/netip"
+	"os"
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata"
 
+	"github.com/Desnn1ch/pr-reviewer-service/internal/app"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/app/scheduler"
 	"github.com/Desnn1ch/pr-reviewer-service/internal/app/service"
 	"github.com/Desnn1ch/pr-reviewer-service/internal/config"
 	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/infrastructure/logging"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/infrastructure/metrics"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/infrastructure/notify"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/infrastructure/oidc"
 	dbinfra "github.com/Desnn1ch/pr-reviewer-service/internal/infrastructure/persistence/db"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/infrastructure/tracing"
 	httpserver "github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver"
 	"github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/handler"
 )
@@ -28,38 +39,168 @@ func main() {
 		return
 	}
 
+	logger, err := logging.New(os.Stdout, cfg.Log.Level, cfg.Log.Format)
+	if err != nil {
+		log.Printf("failed to init logger: %v", err)
+		return
+	}
+	slog.SetDefault(logger)
+
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.Endpoint,
+		Insecure:    cfg.Tracing.Insecure,
+		SampleRatio: cfg.Tracing.SampleRatio,
+		ServiceName: cfg.Tracing.ServiceName,
+	})
+	if err != nil {
+		logger.Error("failed to init tracing", "error", err)
+		return
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			logger.Error("tracing shutdown error", "error", err)
+		}
+	}()
+
 	db, err := dbinfra.New(ctx, dbinfra.Config{
 		DSN:             cfg.Database.DSN(),
 		MigrationsDir:   "./migrations",
 		MaxOpenConns:    cfg.Database.Pool.MaxOpenConns,
 		MaxIdleConns:    cfg.Database.Pool.MaxIdleConns,
 		ConnMaxLifetime: cfg.Database.Pool.ConnMaxLifetime.Duration,
+		TxMaxAttempts:   cfg.Database.TxMaxAttempts,
+		Logger:          logger,
 	})
 	if err != nil {
-		log.Printf("failed to init db: %v", err)
+		logger.Error("failed to init db", "error", err)
 		return
 	}
 	defer func() {
 		if err := db.Close(); err != nil {
-			log.Printf("db close error: %v", err)
+			logger.Error("db close error", "error", err)
 		}
 	}()
 
 	clock := common.StandardClock{}
 
+	prom := metrics.New()
+	prom.RegisterDBStats(db.Stats)
+
 	repos := dbinfra.NewRepositories(db)
 
-	teamSvc := service.NewTeamService(repos.Teams, repos.Users, repos.Tx)
-	userSvc := service.NewUserService(repos.Users, repos.PRs)
-	prSvc := service.NewPRService(repos.PRs, repos.Users, repos.Tx, clock)
-	statsSvc := service.NewStatsService(repos.PRs)
+	teamSvc := service.NewTeamService(repos.Teams, repos.Users, repos.Tx, clock)
+	userSvc := service.NewUserService(repos.Users, repos.PRs, repos.Teams, clock)
+	prSvc := service.NewPRService(repos.PRs, repos.Users, repos.Teams, repos.Tx, clock, prom, logger)
+	statsSvc := service.NewStatsService(repos.PRs, repos.Teams, repos.Users)
+	apiKeySvc := service.NewAPIKeyService(repos.APIKeys, clock, config.Getenv("ADMIN_API_KEY", cfg.Auth.AdminKey))
+
+	jobs := scheduler.New(logger)
+	jobs.Every("leave-reassign", cfg.Jobs.LeaveReassignInterval.Duration, prSvc.ReassignStartedLeaves)
+	jobs.Every("review-escalation", cfg.Jobs.EscalationInterval.Duration, prSvc.EscalateStaleReviews)
+	jobs.Every("open-pr-metrics", cfg.Jobs.MetricsInterval.Duration, prSvc.RefreshOpenPRMetrics)
+
+	var notifiers notify.Multi
+	if c := cfg.Notifications.SMTP; c.Host != "" {
+		notifiers = append(notifiers, notify.NewSMTP(notify.SMTPConfig{
+			Host:     c.Host,
+			Port:     c.Port,
+			Username: c.Username,
+			Password: c.Password,
+			From:     c.From,
+			Timeout:  c.Timeout.Duration,
+		}))
+	}
+	if c := cfg.Notifications.Webhook; c.URL != "" {
+		notifiers = append(notifiers, notify.NewWebhook(c.URL, c.Timeout.Duration))
+	}
+	if len(notifiers) > 0 {
+		notificationSvc := service.NewNotificationService(repos.PRs, repos.Users, notifiers, clock, logger)
+		jobs.Every("review-digest", cfg.Jobs.DigestInterval.Duration, notificationSvc.SendDigests)
+	} else {
+		logger.Info("no notification channel configured, review digests disabled")
+	}
+
+	var idem *handler.IdempotencyMiddleware
+	if cfg.Idempotency.Enabled {
+		idemSvc := service.NewIdempotencyService(repos.Idempotency, clock, cfg.Idempotency.TTL.Duration, logger)
+		jobs.Every("idempotency-cleanup", cfg.Jobs.IdempotencyCleanup.Duration, idemSvc.DeleteExpired)
+		idem = handler.NewIdempotencyMiddleware(idemSvc, logger)
+	}
 
-	teamHandler := handler.NewTeamHandler(teamSvc)
-	userHandler := handler.NewUserHandler(userSvc)
-	prHandler := handler.NewPRHandler(prSvc)
-	statsHandler := handler.NewStatsHandler(statsSvc)
+	jobs.Start(ctx)
+
+	teamHandler := handler.NewTeamHandler(teamSvc, logger)
+	userHandler := handler.NewUserHandler(userSvc, logger)
+	prHandler := handler.NewPRHandler(prSvc, logger)
+	statsHandler := handler.NewStatsHandler(statsSvc, logger)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeySvc, logger)
+
+	var auth *handler.AuthMiddleware
+	if cfg.Auth.Enabled {
+		var tokens app.TokenVerifier
+		if c := cfg.Auth.JWT; c.JWKSURL != "" || c.JWKSFile != "" {
+			verifier, err := oidc.New(ctx, oidc.Config{
+				JWKSURL:      c.JWKSURL,
+				JWKSFile:     c.JWKSFile,
+				Issuer:       c.Issuer,
+				Audience:     c.Audience,
+				SubjectClaim: c.SubjectClaim,
+			})
+			if err != nil {
+				logger.Error("failed to init jwt verifier", "error", err)
+				return
+			}
+			tokens = verifier
+		}
+		auth = handler.NewAuthMiddleware(service.NewAuthService(apiKeySvc, tokens, repos.Users), logger)
+	} else {
+		logger.Warn("authentication disabled, API routes are open")
+	}
 
-	router := httpserver.NewRouter(teamHandler, userHandler, prHandler, statsHandler)
+	healthHandler := handler.NewHealthHandler(
+		handler.HealthCheck{Name: "database", Check: db.Ping},
+		handler.HealthCheck{Name: "migrations", Check: db.CheckMigrations},
+	)
+
+	var limiter *httpserver.RateLimiter
+	if c := cfg.RateLimit; c.Enabled {
+		routes := make(map[string]httpserver.Limit, len(c.Routes))
+		for route, l := range c.Routes {
+			routes[route] = httpserver.Limit{RPS: l.RPS, Burst: l.Burst}
+		}
+		limiter = httpserver.NewRateLimiter(httpserver.RateLimitConfig{
+			PerIP:   httpserver.Limit{RPS: c.PerIP.RPS, Burst: c.PerIP.Burst},
+			Default: httpserver.Limit{RPS: c.RPS, Burst: c.Burst},
+			Routes:  routes,
+		})
+	}
+
+	trusted := make([]netip.Prefix, 0, len(cfg.Server.TrustedProxies))
+	for _, p := range cfg.Server.TrustedProxies {
+		trusted = append(trusted, p.Prefix)
+	}
+
+	router := httpserver.NewRouter(
+		httpserver.Handlers{
+			Team:    teamHandler,
+			User:    userHandler,
+			PR:      prHandler,
+			Stats:   statsHandler,
+			APIKeys: apiKeyHandler,
+			Health:  healthHandler,
+		},
+		httpserver.Middlewares{
+			Auth:           auth,
+			Idempotency:    idem,
+			RateLimit:      limiter,
+			TrustedProxies: trusted,
+		},
+		prom,
+		logger,
+	)
 
 	srv := &http.Server{
 		Addr:         cfg.Server.Address,
@@ -70,27 +211,36 @@ func main() {
 	}
 
 	go func() {
-		log.Printf("listening on %s", cfg.Server.Address)
+		logger.Info("listening", "address", cfg.Server.Address)
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("server error: %v", err)
+			logger.Error("server error", "error", err)
+			stop()
 		}
 	}()
 
 	<-ctx.Done()
-	log.Println("shutdown signal received")
+	logger.Info("shutdown signal received")
+
+	healthHandler.Drain()
+	if d := cfg.Server.DrainDelay.Duration; d > 0 {
+		logger.Info("draining", "delay", d)
+		time.Sleep(d)
+	}
 
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	if err := srv.Shutdown(shutdownCtx); err != nil {
-		log.Printf("server shutdown error: %v", err)
+		logger.Error("server shutdown error", "error", err)
 	} else {
-		log.Println("server shutdown complete")
+		logger.Info("server shutdown complete")
 	}
 
+	jobs.Wait()
+
 	if err := db.Close(); err != nil {
-		log.Printf("db close error: %v", err)
+		logger.Error("db close error", "error", err)
 	} else {
-		log.Println("db connection closed")
+		logger.Info("db connection closed")
 	}
 }
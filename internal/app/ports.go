@@ -2,34 +2,153 @@ package app
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
 )
 
+type IsolationLevel int
+
+const (
+	IsolationDefault IsolationLevel = iota
+	IsolationReadCommitted
+	IsolationRepeatableRead
+	IsolationSerializable
+)
+
+// TxOptions of the zero value start a read-write transaction at the database's default isolation level.
+type TxOptions struct {
+	Isolation IsolationLevel
+	ReadOnly  bool
+}
+
 type TxManager interface {
-	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+	// InTx runs fn in a transaction carried by its context. fn may run again when the transaction
+	// loses a serialization conflict, so it must not have effects outside the transaction.
+	InTx(ctx context.Context, opts TxOptions, fn func(ctx context.Context) error) error
 }
 
 type TeamRepo interface {
 	Create(ctx context.Context, team entity.Team) error
 	GetByName(ctx context.Context, name string) (entity.Team, error)
+
+	GetCodeOwners(ctx context.Context, teamName string) (entity.CodeOwners, error)
+	ReplaceCodeOwners(ctx context.Context, owners entity.CodeOwners) error
+
+	GetRules(ctx context.Context, teamName string) (entity.TeamRules, error)
+	ReplaceRules(ctx context.Context, rules entity.TeamRules) error
+
+	GetSettings(ctx context.Context, teamName string) (entity.TeamSettings, error)
+	UpsertSettings(ctx context.Context, settings entity.TeamSettings) error
+	// ListEscalating returns settings of teams with review escalation enabled.
+	ListEscalating(ctx context.Context) ([]entity.TeamSettings, error)
+
+	// GetRole returns the role granted to the user in the team, or ErrNotFound if none was granted.
+	GetRole(ctx context.Context, teamName string, userID uuid.UUID) (entity.TeamRole, error)
+	// SetRole grants the role; RoleMember revokes a granted role.
+	SetRole(ctx context.Context, role entity.TeamMemberRole) error
+	ListRoles(ctx context.Context, teamName string) ([]entity.TeamMemberRole, error)
 }
 
 type UserRepo interface {
 	GetByID(ctx context.Context, id uuid.UUID) (entity.User, error)
 	ListByTeamName(ctx context.Context, teamName string) ([]entity.User, error)
 	ListActiveByTeamName(ctx context.Context, teamName string) ([]entity.User, error)
-	UpsertMany(ctx context.Context, users []entity.User) error
-	SetActive(ctx context.Context, userID uuid.UUID, isActive bool) error
+	ListActive(ctx context.Context) ([]entity.User, error)
+	// UpsertMany stores the users and logs, as of at, members joining the team and is_active changes.
+	UpsertMany(ctx context.Context, users []entity.User, at time.Time) error
+	SetActive(ctx context.Context, userID uuid.UUID, isActive bool, at time.Time) error
+	SetSkills(ctx context.Context, userID uuid.UUID, skills []string) error
+	SetWorkingHours(ctx context.Context, userID uuid.UUID, wh *entity.WorkingHours) error
+	SetMaxOpenReviews(ctx context.Context, userID uuid.UUID, limit *int) error
+
+	CreateUnavailability(ctx context.Context, u entity.Unavailability) error
+	GetUnavailability(ctx context.Context, id uuid.UUID) (entity.Unavailability, error)
+	UpdateUnavailability(ctx context.Context, u entity.Unavailability) error
+	DeleteUnavailability(ctx context.Context, id uuid.UUID) error
+	ListUnavailability(ctx context.Context, userID uuid.UUID) ([]entity.Unavailability, error)
+	ListUnavailableUserIDs(ctx context.Context, teamName string, at time.Time) ([]uuid.UUID, error)
+	// ListStartedLeaves returns unhandled windows covering at whose team reassigns reviews on leave.
+	ListStartedLeaves(ctx context.Context, at time.Time) ([]entity.Unavailability, error)
+	MarkUnavailabilityHandled(ctx context.Context, id uuid.UUID, at time.Time) error
+	// ListTeamUnavailability returns windows of the team's members overlapping [from, to).
+	ListTeamUnavailability(ctx context.Context, teamName string, from, to time.Time) ([]entity.Unavailability, error)
+	// ListActivityChanges returns is_active transitions and joins of the team's members made before the
+	// given time and the first transition of each member after it, which tells their state up to that time.
+	ListActivityChanges(ctx context.Context, teamName string, before time.Time) ([]entity.ActivityChange, error)
 }
 
 type PRRepo interface {
 	Create(ctx context.Context, pr entity.PR) error
 	GetByID(ctx context.Context, id uuid.UUID) (entity.PR, error)
+	// GetByIDForUpdate is GetByID that locks the PR until the end of the transaction.
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (entity.PR, error)
+	// Update saves pr if pr.Version is still the stored version, which it then increments;
+	// otherwise it returns ErrConflict.
 	Update(ctx context.Context, pr entity.PR) error
 	ListByReviewerID(ctx context.Context, reviewerID uuid.UUID) ([]entity.PR, error)
+	// CountOpenReviews returns the number of open PRs each reviewer of the team is assigned to.
+	CountOpenReviews(ctx context.Context, teamName string) (map[uuid.UUID]int, error)
+	// ListOpenAssignments returns not yet reviewed reviews of the team's members on open PRs
+	// assigned no later than assignedBefore.
+	ListOpenAssignments(ctx context.Context, teamName string, assignedBefore time.Time) ([]entity.ReviewAssignment, error)
+
+	CreateDecision(ctx context.Context, d entity.AssignmentDecision) error
+	ListDecisions(ctx context.Context, prID uuid.UUID) ([]entity.AssignmentDecision, error)
+
+	CreateEvent(ctx context.Context, ev entity.PREvent) error
+	ListEvents(ctx context.Context, prID uuid.UUID) ([]entity.PREvent, error)
+
+	// ListReviewerStats returns every member of the team, including those without reviews.
+	ListReviewerStats(ctx context.Context, teamName string, includeInactive bool) ([]entity.ReviewerStats, error)
+	// ListTeamPRTimes returns PRs authored by the team's members created or merged within [from, to).
+	ListTeamPRTimes(ctx context.Context, teamName string, from, to time.Time) ([]entity.PRTimes, error)
+	// ListFirstReviews returns the first review of each PR by the team's members made within [from, to),
+	// with the assignment that preceded it, including reviewers since reassigned away.
+	ListFirstReviews(ctx context.Context, teamName string, from, to time.Time) ([]entity.FirstReview, error)
+	// CountAssignments returns how many times each team member was assigned as a reviewer within [from, to).
+	CountAssignments(ctx context.Context, teamName string, from, to time.Time) (map[uuid.UUID]int, error)
+	// GetReviewTotals aggregates the user's assignments, reassignments away and reviewed PRs.
+	GetReviewTotals(ctx context.Context, userID uuid.UUID) (entity.ReviewTotals, error)
+	// GetAuthorTotals counts PRs the user authored and how many of them were merged.
+	GetAuthorTotals(ctx context.Context, userID uuid.UUID) (entity.AuthorTotals, error)
+	// CountOpenByTeam returns the number of open PRs authored by each team's members, zero for teams without any.
+	CountOpenByTeam(ctx context.Context) (map[string]int, error)
+}
+
+type APIKeyRepo interface {
+	Create(ctx context.Context, key entity.APIKey) error
+	GetByHash(ctx context.Context, hash string) (entity.APIKey, error)
+	List(ctx context.Context) ([]entity.APIKey, error)
+	Revoke(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+type IdempotencyRepo interface {
+	// Reserve stores rec unless an unexpired record of the same client and key exists, which is
+	// returned instead; created tells which happened.
+	Reserve(ctx context.Context, rec entity.IdempotencyRecord) (stored entity.IdempotencyRecord, created bool, err error)
+	Complete(ctx context.Context, rec entity.IdempotencyRecord) error
+	Delete(ctx context.Context, client, key string) error
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+// TokenVerifier validates a signed token of a person and returns its subject, the user ID.
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (string, error)
+}
+
+// Notifier delivers notifications to users over some channel (email, webhook, ...).
+type Notifier interface {
+	Notify(ctx context.Context, n entity.Notification) error
+}
 
-	ListReviewerStats(ctx context.Context, teamName string) ([]entity.ReviewerStats, error)
+// Metrics records domain events for monitoring.
+type Metrics interface {
+	PRCreated()
+	PRMerged()
+	ReviewerReassigned()
+	NoCandidate()
+	SetOpenPRs(teamName string, n int)
 }
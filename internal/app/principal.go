@@ -0,0 +1,19 @@
+package app
+
+import (
+	"context"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, p entity.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the authenticated caller, if the request was authenticated.
+func PrincipalFromContext(ctx context.Context) (entity.Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(entity.Principal)
+	return p, ok
+}
@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+type job struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+}
+
+type Scheduler struct {
+	jobs []job
+	wg   sync.WaitGroup
+	log  *slog.Logger
+}
+
+func New(log *slog.Logger) *Scheduler {
+	return &Scheduler{log: log}
+}
+
+// Every registers run to be called each interval. Jobs with a non-positive interval are disabled.
+func (s *Scheduler) Every(name string, interval time.Duration, run func(ctx context.Context) error) {
+	if interval <= 0 {
+		s.log.Info("job disabled", "job", name)
+		return
+	}
+	s.jobs = append(s.jobs, job{name: name, interval: interval, run: run})
+}
+
+// Start runs every job in its own goroutine until ctx is done.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go func(j job) {
+			defer s.wg.Done()
+			s.loop(ctx, j)
+		}(j)
+	}
+}
+
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, j job) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.run(ctx); err != nil && ctx.Err() == nil {
+				s.log.ErrorContext(ctx, "job failed", "job", j.name, "error", err)
+			}
+		}
+	}
+}
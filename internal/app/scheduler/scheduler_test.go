@@ -0,0 +1,46 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScheduler_RunsJobsUntilCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var runs atomic.Int32
+	done := make(chan struct{})
+
+	s := New(slog.New(slog.DiscardHandler))
+	s.Every("counter", 5*time.Millisecond, func(ctx context.Context) error {
+		if runs.Add(1) == 3 {
+			close(done)
+		}
+		return errors.New("failures do not stop the job")
+	})
+	s.Every("disabled", 0, func(ctx context.Context) error {
+		t.Errorf("disabled job must not run")
+		return nil
+	})
+
+	s.Start(ctx)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("job did not run 3 times, got %d", runs.Load())
+	}
+
+	cancel()
+	s.Wait()
+
+	after := runs.Load()
+	time.Sleep(20 * time.Millisecond)
+	if runs.Load() != after {
+		t.Fatalf("job kept running after cancel")
+	}
+}
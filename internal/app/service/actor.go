@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/app"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+// actorFromContext returns the user performing the operation. Service keys, background jobs and
+// deployments without authentication have no actor and are limited by route scopes only.
+func actorFromContext(ctx context.Context) (entity.Principal, bool) {
+	p, ok := app.PrincipalFromContext(ctx)
+	if !ok || !p.IsUser() {
+		return entity.Principal{}, false
+	}
+	return p, true
+}
+
+// roleOf returns the actor's role in the team: a granted role, RoleMember for its members or RoleNone.
+func roleOf(ctx context.Context, teams app.TeamRepo, actor entity.Principal, teamName string) (entity.TeamRole, error) {
+	role, err := teams.GetRole(ctx, teamName, actor.UserID)
+	if err == nil {
+		return role, nil
+	}
+	if !errors.Is(err, common.ErrNotFound) {
+		return entity.RoleNone, err
+	}
+
+	if actor.TeamName == teamName {
+		return entity.RoleMember, nil
+	}
+	return entity.RoleNone, nil
+}
+
+// requireTeamRole fails with ErrForbidden unless the actor has at least min in the team.
+func requireTeamRole(ctx context.Context, teams app.TeamRepo, teamName string, min entity.TeamRole) error {
+	return requireSelfOrTeamRole(ctx, teams, teamName, min)
+}
+
+// requireSelfOrTeamRole is requireTeamRole that also lets through an actor listed in self.
+func requireSelfOrTeamRole(ctx context.Context, teams app.TeamRepo, teamName string, min entity.TeamRole, self ...uuid.UUID) error {
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	for _, id := range self {
+		if id == actor.UserID {
+			return nil
+		}
+	}
+
+	role, err := roleOf(ctx, teams, actor, teamName)
+	if err != nil {
+		return err
+	}
+	if !role.AtLeast(min) {
+		return common.ErrForbidden
+	}
+
+	return nil
+}
+
+// authorizePRActor allows the actor if it is one of allowed or a lead of the PR author's team.
+func (s *PRService) authorizePRActor(ctx context.Context, pr entity.PR, allowed ...uuid.UUID) error {
+	if _, ok := actorFromContext(ctx); !ok {
+		return nil
+	}
+
+	author, err := s.users.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	return requireSelfOrTeamRole(ctx, s.teams, author.TeamName, entity.RoleLead, allowed...)
+}
+
+// teamLeads returns the users holding the lead role in the team.
+func teamLeads(ctx context.Context, teams app.TeamRepo, teamName string) ([]uuid.UUID, error) {
+	roles, err := teams.ListRoles(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	var leads []uuid.UUID
+	for _, r := range roles {
+		if r.Role == entity.RoleLead {
+			leads = append(leads, r.UserID)
+		}
+	}
+	return leads, nil
+}
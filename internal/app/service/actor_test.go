@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/app"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+func TestPRService_ActorChecks(t *testing.T) {
+	authorID := uuid.New()
+	leadID := uuid.New()
+	reviewerID := uuid.New()
+	otherReviewerID := uuid.New()
+	candidateID := uuid.New()
+	outsiderID := uuid.New()
+
+	userAs := func(id uuid.UUID) context.Context {
+		return app.WithPrincipal(context.Background(), entity.Principal{UserID: id, Scopes: entity.UserScopes})
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		merge   bool
+		review  bool
+		wantErr error
+	}{
+		{name: "author merges", ctx: userAs(authorID), merge: true},
+		{name: "lead merges", ctx: userAs(leadID), merge: true},
+		{name: "reviewer cannot merge", ctx: userAs(reviewerID), merge: true, wantErr: common.ErrForbidden},
+		{name: "service key merges", ctx: app.WithPrincipal(context.Background(), entity.Principal{KeyID: uuid.New()}), merge: true},
+		{name: "reviewer reassigns self", ctx: userAs(reviewerID)},
+		{name: "lead reassigns", ctx: userAs(leadID)},
+		{name: "other reviewer cannot reassign", ctx: userAs(otherReviewerID), wantErr: common.ErrForbidden},
+		{name: "outsider cannot reassign", ctx: userAs(outsiderID), wantErr: common.ErrForbidden},
+		{name: "unauthenticated reassigns", ctx: context.Background()},
+		{name: "reviewer records own review", ctx: userAs(reviewerID), review: true},
+		{name: "lead records review", ctx: userAs(leadID), review: true},
+		{name: "author cannot record review", ctx: userAs(authorID), review: true, wantErr: common.ErrForbidden},
+		{name: "outsider cannot record review", ctx: userAs(outsiderID), review: true, wantErr: common.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := newFakeUserRepo()
+			prRepo := newFakePRRepo()
+			teamRepo := newFakeTeamRepo()
+
+			for _, id := range []uuid.UUID{authorID, leadID, reviewerID, otherReviewerID, candidateID} {
+				userRepo.users[id] = entity.User{ID: id, TeamName: teamName, Name: id.String(), IsActive: true}
+			}
+			userRepo.users[outsiderID] = entity.User{ID: outsiderID, TeamName: "frontend", Name: "Outsider", IsActive: true}
+
+			teamRepo.roles[teamName] = map[uuid.UUID]entity.TeamRole{leadID: entity.RoleLead}
+
+			prID := uuid.New()
+			prRepo.prs[prID] = entity.PR{
+				ID:        prID,
+				Title:     "PR",
+				AuthorID:  authorID,
+				Status:    entity.StatusOpen,
+				Reviewers: []uuid.UUID{reviewerID, otherReviewerID},
+			}
+
+			svc := NewPRService(prRepo, userRepo, teamRepo, fakeTx{}, common.StandardClock{}, &fakeMetrics{}, discardLogger)
+
+			var err error
+			switch {
+			case tt.merge:
+				_, err = svc.Merge(tt.ctx, prID, 0)
+			case tt.review:
+				_, err = svc.RecordReview(tt.ctx, prID, reviewerID)
+			default:
+				_, _, err = svc.ReassignReviewer(tt.ctx, prID, reviewerID, 0)
+			}
+
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				if pr := prRepo.prs[prID]; pr.IsMerged() || len(pr.Reviewers) != 2 || pr.Reviewers[0] != reviewerID || len(prRepo.events) != 0 {
+					t.Fatalf("forbidden call must not change the PR: %+v, events %+v", pr, prRepo.events)
+				}
+			}
+		})
+	}
+}
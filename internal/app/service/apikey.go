@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/app"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+const (
+	apiKeySecretPrefix = "prs_"
+	apiKeyPrefixLen    = len(apiKeySecretPrefix) + 8
+)
+
+type APIKeyService struct {
+	keys  app.APIKeyRepo
+	clock common.Clock
+	// adminKey is a configured secret with the admin scope, used to create the first keys.
+	adminKey string
+}
+
+func NewAPIKeyService(keys app.APIKeyRepo, clock common.Clock, adminKey string) *APIKeyService {
+	return &APIKeyService{
+		keys:     keys,
+		clock:    clock,
+		adminKey: adminKey,
+	}
+}
+
+// Create stores a new key and returns it with its secret, which is not retrievable later.
+func (s *APIKeyService) Create(ctx context.Context, name string, scopes []entity.Scope) (entity.APIKey, string, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyService.Create")
+	defer span.End()
+
+	if strings.TrimSpace(name) == "" || len(scopes) == 0 {
+		return entity.APIKey{}, "", common.ErrInvalidAPIKey
+	}
+	for _, sc := range scopes {
+		if !sc.IsValid() {
+			return entity.APIKey{}, "", common.ErrInvalidAPIKey
+		}
+	}
+
+	secret, err := newAPIKeySecret()
+	if err != nil {
+		return entity.APIKey{}, "", err
+	}
+
+	key := entity.APIKey{
+		ID:        uuid.New(),
+		Name:      name,
+		Prefix:    secret[:apiKeyPrefixLen],
+		Hash:      hashAPIKey(secret),
+		Scopes:    scopes,
+		CreatedAt: s.clock.Now(),
+	}
+
+	if err := s.keys.Create(ctx, key); err != nil {
+		return entity.APIKey{}, "", err
+	}
+
+	return key, secret, nil
+}
+
+func (s *APIKeyService) List(ctx context.Context) ([]entity.APIKey, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyService.List")
+	defer span.End()
+
+	return s.keys.List(ctx)
+}
+
+func (s *APIKeyService) Revoke(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "APIKeyService.Revoke")
+	defer span.End()
+
+	return s.keys.Revoke(ctx, id, s.clock.Now())
+}
+
+// Authenticate resolves a bearer secret to its caller; unknown and revoked keys are ErrUnauthorized.
+func (s *APIKeyService) Authenticate(ctx context.Context, secret string) (entity.Principal, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyService.Authenticate")
+	defer span.End()
+
+	if secret == "" {
+		return entity.Principal{}, common.ErrUnauthorized
+	}
+
+	if s.adminKey != "" && subtle.ConstantTimeCompare([]byte(secret), []byte(s.adminKey)) == 1 {
+		return entity.Principal{Name: "admin", Scopes: []entity.Scope{entity.ScopeAdmin}}, nil
+	}
+
+	key, err := s.keys.GetByHash(ctx, hashAPIKey(secret))
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return entity.Principal{}, common.ErrUnauthorized
+		}
+		return entity.Principal{}, err
+	}
+	if key.IsRevoked() {
+		return entity.Principal{}, common.ErrUnauthorized
+	}
+
+	return entity.Principal{KeyID: key.ID, Name: key.Name, Scopes: key.Scopes}, nil
+}
+
+func newAPIKeySecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return apiKeySecretPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
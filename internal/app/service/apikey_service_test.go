@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+func TestAPIKeyService_CreateAuthenticateRevoke(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeAPIKeyRepo()
+	svc := NewAPIKeyService(repo, fakeClock{now: time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)}, "")
+
+	key, secret, err := svc.Create(ctx, "ci", []entity.Scope{entity.ScopePRsWrite})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	if !strings.HasPrefix(secret, key.Prefix) {
+		t.Fatalf("prefix %q is not the start of the secret", key.Prefix)
+	}
+	if stored := repo.keys[key.ID]; stored.Hash == secret || strings.Contains(stored.Hash, secret) {
+		t.Fatalf("secret must not be stored in plain text")
+	}
+
+	p, err := svc.Authenticate(ctx, secret)
+	if err != nil {
+		t.Fatalf("Authenticate returned error: %v", err)
+	}
+	if p.KeyID != key.ID || !p.HasScope(entity.ScopePRsWrite) || p.HasScope(entity.ScopeStatsRead) {
+		t.Fatalf("unexpected principal %+v", p)
+	}
+
+	if _, err := svc.Authenticate(ctx, secret+"x"); !errors.Is(err, common.ErrUnauthorized) {
+		t.Fatalf("unknown key: expected ErrUnauthorized, got %v", err)
+	}
+
+	if err := svc.Revoke(ctx, key.ID); err != nil {
+		t.Fatalf("Revoke returned error: %v", err)
+	}
+	if _, err := svc.Authenticate(ctx, secret); !errors.Is(err, common.ErrUnauthorized) {
+		t.Fatalf("revoked key: expected ErrUnauthorized, got %v", err)
+	}
+	if err := svc.Revoke(ctx, key.ID); !errors.Is(err, common.ErrNotFound) {
+		t.Fatalf("second revoke: expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestAPIKeyService_Create_Invalid(t *testing.T) {
+	svc := NewAPIKeyService(newFakeAPIKeyRepo(), fakeClock{}, "")
+
+	tests := []struct {
+		name   string
+		key    string
+		scopes []entity.Scope
+	}{
+		{name: "empty name", key: " ", scopes: []entity.Scope{entity.ScopeStatsRead}},
+		{name: "no scopes", key: "ci"},
+		{name: "unknown scope", key: "ci", scopes: []entity.Scope{"prs:delete"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := svc.Create(context.Background(), tt.key, tt.scopes); !errors.Is(err, common.ErrInvalidAPIKey) {
+				t.Fatalf("expected ErrInvalidAPIKey, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAPIKeyService_AdminKey(t *testing.T) {
+	svc := NewAPIKeyService(newFakeAPIKeyRepo(), fakeClock{}, "bootstrap-secret")
+
+	p, err := svc.Authenticate(context.Background(), "bootstrap-secret")
+	if err != nil {
+		t.Fatalf("Authenticate returned error: %v", err)
+	}
+	if !p.HasScope(entity.ScopeAdmin) || !p.HasScope(entity.ScopeTeamsWrite) {
+		t.Fatalf("admin key must grant every scope, got %+v", p.Scopes)
+	}
+
+	if _, err := svc.Authenticate(context.Background(), ""); !errors.Is(err, common.ErrUnauthorized) {
+		t.Fatalf("empty secret: expected ErrUnauthorized, got %v", err)
+	}
+}
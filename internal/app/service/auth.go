@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/app"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+// AuthService resolves bearer credentials: JWTs of people when a verifier is configured, API keys otherwise.
+type AuthService struct {
+	keys   *APIKeyService
+	tokens app.TokenVerifier
+	users  app.UserRepo
+}
+
+// NewAuthService accepts a nil tokens verifier, in which case only API keys are accepted.
+func NewAuthService(keys *APIKeyService, tokens app.TokenVerifier, users app.UserRepo) *AuthService {
+	return &AuthService{
+		keys:   keys,
+		tokens: tokens,
+		users:  users,
+	}
+}
+
+func (s *AuthService) Authenticate(ctx context.Context, credential string) (entity.Principal, error) {
+	if s.tokens == nil || !isJWT(credential) {
+		return s.keys.Authenticate(ctx, credential)
+	}
+
+	ctx, span := tracer.Start(ctx, "AuthService.Authenticate")
+	defer span.End()
+
+	sub, err := s.tokens.Verify(ctx, credential)
+	if err != nil {
+		return entity.Principal{}, err
+	}
+
+	userID, err := uuid.Parse(sub)
+	if err != nil {
+		return entity.Principal{}, common.ErrUnauthorized
+	}
+
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return entity.Principal{}, common.ErrUnauthorized
+		}
+		return entity.Principal{}, err
+	}
+
+	return entity.Principal{
+		UserID:   user.ID,
+		TeamName: user.TeamName,
+		Name:     user.Name,
+		Scopes:   entity.UserScopes,
+	}, nil
+}
+
+// isJWT tells a compact JWS (header.payload.signature) from an API key secret.
+func isJWT(credential string) bool {
+	return strings.Count(credential, ".") == 2
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+type fakeTokenVerifier struct {
+	subjects map[string]string
+}
+
+func (v fakeTokenVerifier) Verify(ctx context.Context, token string) (string, error) {
+	sub, ok := v.subjects[token]
+	if !ok {
+		return "", common.ErrUnauthorized
+	}
+	return sub, nil
+}
+
+func TestAuthService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newFakeUserRepo()
+	userID := uuid.New()
+	userRepo.users[userID] = entity.User{ID: userID, TeamName: teamName, Name: "Alice", IsActive: true}
+
+	keys := NewAPIKeyService(newFakeAPIKeyRepo(), fakeClock{}, "")
+	_, secret, err := keys.Create(ctx, "ci", []entity.Scope{entity.ScopePRsWrite})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	verifier := fakeTokenVerifier{subjects: map[string]string{
+		"h.alice.s":   userID.String(),
+		"h.ghost.s":   uuid.NewString(),
+		"h.notuuid.s": "alice@example.com",
+	}}
+	svc := NewAuthService(keys, verifier, userRepo)
+
+	p, err := svc.Authenticate(ctx, "h.alice.s")
+	if err != nil {
+		t.Fatalf("token: unexpected error: %v", err)
+	}
+	if !p.IsUser() || p.UserID != userID || p.TeamName != teamName {
+		t.Fatalf("token: unexpected principal %+v", p)
+	}
+	if p.HasScope(entity.ScopeAdmin) {
+		t.Fatalf("token users must not get the admin scope")
+	}
+
+	for _, token := range []string{"h.ghost.s", "h.notuuid.s", "h.forged.s"} {
+		if _, err := svc.Authenticate(ctx, token); !errors.Is(err, common.ErrUnauthorized) {
+			t.Fatalf("%s: expected ErrUnauthorized, got %v", token, err)
+		}
+	}
+
+	p, err = svc.Authenticate(ctx, secret)
+	if err != nil {
+		t.Fatalf("api key: unexpected error: %v", err)
+	}
+	if p.IsUser() || !p.HasScope(entity.ScopePRsWrite) {
+		t.Fatalf("api key: unexpected principal %+v", p)
+	}
+}
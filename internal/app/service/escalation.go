@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+// EscalateStaleReviews acts on reviews waiting longer than their team's escalation threshold:
+// the reviewer is replaced or the team lead is added, depending on the team policy.
+// Every action is recorded as a PR event. Reviews that cannot be escalated stay as they are.
+func (s *PRService) EscalateStaleReviews(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "PRService.EscalateStaleReviews")
+	defer span.End()
+
+	teams, err := s.teams.ListEscalating(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, settings := range teams {
+		now := s.clock.Now()
+
+		assignments, err := s.prs.ListOpenAssignments(ctx, settings.TeamName, now.Add(-settings.EscalateAfter))
+		if err != nil {
+			return err
+		}
+
+		for _, a := range assignments {
+			age := a.Age(now)
+			if age <= settings.EscalateAfter {
+				continue
+			}
+
+			detail := fmt.Sprintf("no review for %s, team threshold %s", age.Round(time.Second), settings.EscalateAfter)
+
+			err := s.tx.InTx(ctx, selectionTx, func(txCtx context.Context) error {
+				switch settings.EscalationPolicy {
+				case entity.EscalationAddLead:
+					return s.addLead(txCtx, a, settings.TeamName, detail)
+				default:
+					return s.escalateReassign(txCtx, a, detail)
+				}
+			})
+			s.countNoCandidate(err)
+			switch {
+			case errors.Is(err, common.ErrNoCandidate), errors.Is(err, common.ErrRulesUnsatisfied),
+				errors.Is(err, common.ErrPRMerged), errors.Is(err, common.ErrNotAssigned):
+				s.log.WarnContext(ctx, "escalation: keep review",
+					"pr_id", a.PRID, "reviewer_id", a.ReviewerID, "team", settings.TeamName, "error", err)
+			case err != nil:
+				return err
+			case settings.EscalationPolicy != entity.EscalationAddLead:
+				s.metrics.ReviewerReassigned()
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *PRService) escalateReassign(txCtx context.Context, a entity.ReviewAssignment, detail string) error {
+	pr, choice, err := s.reassign(txCtx, a.PRID, a.ReviewerID, 0)
+	if err != nil {
+		return err
+	}
+
+	return s.prs.CreateEvent(txCtx, entity.PREvent{
+		ID:            uuid.New(),
+		PRID:          pr.ID,
+		Kind:          entity.EventEscalationReassigned,
+		ReviewerID:    a.ReviewerID,
+		NewReviewerID: choice.UserID,
+		Detail:        detail,
+		CreatedAt:     s.clock.Now(),
+	})
+}
+
+// addLead adds a lead of the team to the PR once; PRs a lead already reviews are left alone. Without
+// an active lead other than the author the review is kept with ErrNoCandidate.
+func (s *PRService) addLead(txCtx context.Context, a entity.ReviewAssignment, teamName, detail string) error {
+	pr, err := s.prs.GetByIDForUpdate(txCtx, a.PRID)
+	if err != nil {
+		return err
+	}
+
+	if pr.IsMerged() {
+		return common.ErrPRMerged
+	}
+
+	leads, err := teamLeads(txCtx, s.teams, teamName)
+	if err != nil {
+		return err
+	}
+
+	leadID := uuid.Nil
+	for _, id := range leads {
+		if pr.HasReviewer(id) {
+			return nil
+		}
+		if id == pr.AuthorID || leadID != uuid.Nil {
+			continue
+		}
+
+		lead, err := s.users.GetByID(txCtx, id)
+		if err != nil {
+			return err
+		}
+		if lead.IsActive && lead.TeamName == teamName {
+			leadID = id
+		}
+	}
+	if leadID == uuid.Nil {
+		return common.ErrNoCandidate
+	}
+
+	now := s.clock.Now()
+
+	pr.Reviewers = append(pr.Reviewers, leadID)
+	if pr.AssignedAt == nil {
+		pr.AssignedAt = make(map[uuid.UUID]time.Time)
+	}
+	pr.AssignedAt[leadID] = now
+
+	if err := s.prs.Update(txCtx, pr); err != nil {
+		return err
+	}
+
+	return s.prs.CreateEvent(txCtx, entity.PREvent{
+		ID:            uuid.New(),
+		PRID:          pr.ID,
+		Kind:          entity.EventEscalationLeadAdded,
+		ReviewerID:    a.ReviewerID,
+		NewReviewerID: leadID,
+		Detail:        detail,
+		CreatedAt:     now,
+	})
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+func TestPRService_EscalateStaleReviews_Reassign(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	userRepo := newFakeUserRepo()
+	prRepo := newFakePRRepo()
+	teamRepo := newFakeTeamRepo()
+
+	authorID := uuid.New()
+	stale := uuid.New()
+	fresh := uuid.New()
+	replacement := uuid.New()
+
+	userRepo.users[authorID] = entity.User{ID: authorID, TeamName: teamName, Name: "Author", IsActive: true}
+	userRepo.users[stale] = entity.User{ID: stale, TeamName: teamName, Name: "Stale", IsActive: true}
+	userRepo.users[fresh] = entity.User{ID: fresh, TeamName: teamName, Name: "Fresh", IsActive: true}
+	userRepo.users[replacement] = entity.User{ID: replacement, TeamName: teamName, Name: "Replacement", IsActive: true}
+
+	teamRepo.settings[teamName] = entity.TeamSettings{
+		TeamName:         teamName,
+		CapacityPolicy:   entity.CapacityStrict,
+		EscalateAfter:    48 * time.Hour,
+		EscalationPolicy: entity.EscalationReassign,
+	}
+
+	prID := uuid.New()
+	prRepo.prs[prID] = entity.PR{
+		ID:        prID,
+		AuthorID:  authorID,
+		Status:    entity.StatusOpen,
+		CreatedAt: now.Add(-72 * time.Hour),
+		Reviewers: []uuid.UUID{stale, fresh},
+		AssignedAt: map[uuid.UUID]time.Time{
+			stale: now.Add(-72 * time.Hour),
+			fresh: now.Add(-time.Hour),
+		},
+	}
+
+	svc := NewPRService(prRepo, userRepo, teamRepo, fakeTx{}, fakeClock{now: now}, &fakeMetrics{}, discardLogger)
+
+	if err := svc.EscalateStaleReviews(ctx); err != nil {
+		t.Fatalf("EscalateStaleReviews returned error: %v", err)
+	}
+
+	pr := prRepo.prs[prID]
+	if pr.Reviewers[0] != replacement || pr.Reviewers[1] != fresh {
+		t.Fatalf("expected reviewers [%s %s], got %v", replacement, fresh, pr.Reviewers)
+	}
+	if !pr.ReviewerAssignedAt(replacement).Equal(now) {
+		t.Fatalf("replacement must be assigned now, got %v", pr.ReviewerAssignedAt(replacement))
+	}
+
+	events, _ := prRepo.ListEvents(ctx, prID)
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %+v", events)
+	}
+	ev := events[0]
+	if ev.Kind != entity.EventEscalationReassigned || ev.ReviewerID != stale || ev.NewReviewerID != replacement {
+		t.Fatalf("unexpected event %+v", ev)
+	}
+	if len(prRepo.decisions) != 1 || prRepo.decisions[0].ReplacedUserID != stale {
+		t.Fatalf("reassignment decision must be recorded, got %+v", prRepo.decisions)
+	}
+
+	if err := svc.EscalateStaleReviews(ctx); err != nil {
+		t.Fatalf("EscalateStaleReviews returned error: %v", err)
+	}
+	if events, _ := prRepo.ListEvents(ctx, prID); len(events) != 1 {
+		t.Fatalf("fresh reviews must not be escalated again, got %+v", events)
+	}
+}
+
+func TestPRService_EscalateStaleReviews_AddLead(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	userRepo := newFakeUserRepo()
+	prRepo := newFakePRRepo()
+	teamRepo := newFakeTeamRepo()
+
+	authorID := uuid.New()
+	stale := uuid.New()
+	leadID := uuid.New()
+
+	userRepo.users[authorID] = entity.User{ID: authorID, TeamName: teamName, Name: "Author", IsActive: true}
+	userRepo.users[stale] = entity.User{ID: stale, TeamName: teamName, Name: "Stale", IsActive: true}
+	userRepo.users[leadID] = entity.User{ID: leadID, TeamName: teamName, Name: "Lead", IsActive: true}
+
+	teamRepo.settings[teamName] = entity.TeamSettings{
+		TeamName:         teamName,
+		CapacityPolicy:   entity.CapacityStrict,
+		EscalateAfter:    24 * time.Hour,
+		EscalationPolicy: entity.EscalationAddLead,
+	}
+	teamRepo.roles[teamName] = map[uuid.UUID]entity.TeamRole{leadID: entity.RoleLead}
+
+	prID := uuid.New()
+	prRepo.prs[prID] = entity.PR{
+		ID:        prID,
+		AuthorID:  authorID,
+		Status:    entity.StatusOpen,
+		CreatedAt: now.Add(-30 * time.Hour),
+		Reviewers: []uuid.UUID{stale},
+	}
+
+	svc := NewPRService(prRepo, userRepo, teamRepo, fakeTx{}, fakeClock{now: now}, &fakeMetrics{}, discardLogger)
+
+	for i := 0; i < 2; i++ {
+		if err := svc.EscalateStaleReviews(ctx); err != nil {
+			t.Fatalf("EscalateStaleReviews returned error: %v", err)
+		}
+	}
+
+	pr := prRepo.prs[prID]
+	if len(pr.Reviewers) != 2 || pr.Reviewers[0] != stale || pr.Reviewers[1] != leadID {
+		t.Fatalf("expected lead added next to the stale reviewer, got %v", pr.Reviewers)
+	}
+
+	events, _ := prRepo.ListEvents(ctx, prID)
+	if len(events) != 1 || events[0].Kind != entity.EventEscalationLeadAdded || events[0].NewReviewerID != leadID {
+		t.Fatalf("expected a single lead_added event, got %+v", events)
+	}
+}
+
+func TestPRService_EscalateStaleReviews_AddLead_NoLead(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	userRepo := newFakeUserRepo()
+	prRepo := newFakePRRepo()
+	teamRepo := newFakeTeamRepo()
+
+	authorID := uuid.New()
+	stale := uuid.New()
+	formerLead := uuid.New()
+
+	userRepo.users[authorID] = entity.User{ID: authorID, TeamName: teamName, Name: "Author", IsActive: true}
+	userRepo.users[stale] = entity.User{ID: stale, TeamName: teamName, Name: "Stale", IsActive: true}
+	userRepo.users[formerLead] = entity.User{ID: formerLead, TeamName: teamName, Name: "Former lead", IsActive: true}
+
+	teamRepo.settings[teamName] = entity.TeamSettings{
+		TeamName:         teamName,
+		CapacityPolicy:   entity.CapacityStrict,
+		EscalateAfter:    24 * time.Hour,
+		EscalationPolicy: entity.EscalationAddLead,
+	}
+	// The lead was demoted after the policy was set; only team_roles decides who leads.
+	teamRepo.roles[teamName] = map[uuid.UUID]entity.TeamRole{authorID: entity.RoleLead, formerLead: entity.RoleAdmin}
+
+	prID := uuid.New()
+	prRepo.prs[prID] = entity.PR{
+		ID:        prID,
+		AuthorID:  authorID,
+		Status:    entity.StatusOpen,
+		CreatedAt: now.Add(-30 * time.Hour),
+		Reviewers: []uuid.UUID{stale},
+	}
+
+	metrics := &fakeMetrics{}
+	svc := NewPRService(prRepo, userRepo, teamRepo, fakeTx{}, fakeClock{now: now}, metrics, discardLogger)
+
+	if err := svc.EscalateStaleReviews(ctx); err != nil {
+		t.Fatalf("EscalateStaleReviews returned error: %v", err)
+	}
+
+	if pr := prRepo.prs[prID]; len(pr.Reviewers) != 1 || pr.Reviewers[0] != stale {
+		t.Fatalf("without a lead other than the author the review must be kept, got %v", pr.Reviewers)
+	}
+	if events, _ := prRepo.ListEvents(ctx, prID); len(events) != 0 {
+		t.Fatalf("expected no events, got %+v", events)
+	}
+	if metrics.noCandidate != 1 {
+		t.Fatalf("expected the missing lead to count as no candidate, got %d", metrics.noCandidate)
+	}
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/app"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+type IdempotencyService struct {
+	repo  app.IdempotencyRepo
+	clock common.Clock
+	ttl   time.Duration
+	log   *slog.Logger
+}
+
+func NewIdempotencyService(repo app.IdempotencyRepo, clock common.Clock, ttl time.Duration, log *slog.Logger) *IdempotencyService {
+	return &IdempotencyService{
+		repo:  repo,
+		clock: clock,
+		ttl:   ttl,
+		log:   log,
+	}
+}
+
+// Begin claims the key for a request. It returns the stored response to replay when the same
+// request was already completed, ErrIdempotencyMismatch when the key was used for another request
+// and ErrIdempotencyPending while the first request is still running.
+func (s *IdempotencyService) Begin(ctx context.Context, client, key, requestHash string) (entity.IdempotencyRecord, bool, error) {
+	ctx, span := tracer.Start(ctx, "IdempotencyService.Begin")
+	defer span.End()
+
+	now := s.clock.Now()
+	stored, created, err := s.repo.Reserve(ctx, entity.IdempotencyRecord{
+		Client:      client,
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.ttl),
+	})
+	if err != nil {
+		return entity.IdempotencyRecord{}, false, err
+	}
+	if created {
+		return entity.IdempotencyRecord{}, false, nil
+	}
+
+	if stored.RequestHash != requestHash {
+		return entity.IdempotencyRecord{}, false, common.ErrIdempotencyMismatch
+	}
+	if stored.IsPending() {
+		return entity.IdempotencyRecord{}, false, common.ErrIdempotencyPending
+	}
+
+	return stored, true, nil
+}
+
+// Complete stores the response of a claimed key for replays.
+func (s *IdempotencyService) Complete(ctx context.Context, rec entity.IdempotencyRecord) error {
+	ctx, span := tracer.Start(ctx, "IdempotencyService.Complete")
+	defer span.End()
+
+	return s.repo.Complete(ctx, rec)
+}
+
+// Release frees a claimed key without a response, so the client can retry a failed request.
+func (s *IdempotencyService) Release(ctx context.Context, client, key string) error {
+	ctx, span := tracer.Start(ctx, "IdempotencyService.Release")
+	defer span.End()
+
+	return s.repo.Delete(ctx, client, key)
+}
+
+// DeleteExpired removes keys past their TTL.
+func (s *IdempotencyService) DeleteExpired(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "IdempotencyService.DeleteExpired")
+	defer span.End()
+
+	n, err := s.repo.DeleteExpired(ctx, s.clock.Now())
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		s.log.InfoContext(ctx, "expired idempotency keys deleted", "count", n)
+	}
+
+	return nil
+}
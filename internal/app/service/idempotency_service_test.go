@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+func TestIdempotencyService_BeginCompleteReplay(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeIdempotencyRepo()
+	svc := NewIdempotencyService(repo, fakeClock{now: time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)}, time.Hour, slog.New(slog.DiscardHandler))
+
+	if _, replay, err := svc.Begin(ctx, "key:ci", "k1", "h1"); err != nil || replay {
+		t.Fatalf("first Begin: replay=%v err=%v", replay, err)
+	}
+
+	if _, _, err := svc.Begin(ctx, "key:ci", "k1", "h1"); !errors.Is(err, common.ErrIdempotencyPending) {
+		t.Fatalf("repeat while running: expected ErrIdempotencyPending, got %v", err)
+	}
+
+	err := svc.Complete(ctx, entity.IdempotencyRecord{
+		Client:      "key:ci",
+		Key:         "k1",
+		StatusCode:  200,
+		ContentType: "application/json",
+		ETag:        `"3"`,
+		Body:        []byte(`{"ok":true}`),
+	})
+	if err != nil {
+		t.Fatalf("Complete returned error: %v", err)
+	}
+
+	stored, replay, err := svc.Begin(ctx, "key:ci", "k1", "h1")
+	if err != nil || !replay {
+		t.Fatalf("repeat: replay=%v err=%v", replay, err)
+	}
+	if stored.StatusCode != 200 || stored.ETag != `"3"` || string(stored.Body) != `{"ok":true}` {
+		t.Fatalf("unexpected stored response %+v", stored)
+	}
+
+	if _, _, err := svc.Begin(ctx, "key:ci", "k1", "h2"); !errors.Is(err, common.ErrIdempotencyMismatch) {
+		t.Fatalf("other request: expected ErrIdempotencyMismatch, got %v", err)
+	}
+
+	if _, replay, err := svc.Begin(ctx, "user:1", "k1", "h2"); err != nil || replay {
+		t.Fatalf("same key of another client: replay=%v err=%v", replay, err)
+	}
+}
+
+func TestIdempotencyService_ReleaseAndExpiry(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeIdempotencyRepo()
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	svc := NewIdempotencyService(repo, fakeClock{now: now}, time.Hour, slog.New(slog.DiscardHandler))
+
+	if _, _, err := svc.Begin(ctx, "key:ci", "k1", "h1"); err != nil {
+		t.Fatalf("Begin returned error: %v", err)
+	}
+	if err := svc.Release(ctx, "key:ci", "k1"); err != nil {
+		t.Fatalf("Release returned error: %v", err)
+	}
+	if _, replay, err := svc.Begin(ctx, "key:ci", "k1", "h2"); err != nil || replay {
+		t.Fatalf("after release: replay=%v err=%v", replay, err)
+	}
+
+	later := NewIdempotencyService(repo, fakeClock{now: now.Add(2 * time.Hour)}, time.Hour, slog.New(slog.DiscardHandler))
+	if err := later.DeleteExpired(ctx); err != nil {
+		t.Fatalf("DeleteExpired returned error: %v", err)
+	}
+	if len(repo.records) != 0 {
+		t.Fatalf("expected expired keys to be deleted, got %d", len(repo.records))
+	}
+}
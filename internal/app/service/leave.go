@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+)
+
+// ReassignStartedLeaves moves open reviews away from users whose leave has just started
+// in teams with reassign_on_leave enabled. Reviews without a replacement candidate satisfying the
+// team rules stay assigned.
+func (s *PRService) ReassignStartedLeaves(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "PRService.ReassignStartedLeaves")
+	defer span.End()
+
+	now := s.clock.Now()
+
+	leaves, err := s.users.ListStartedLeaves(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, leave := range leaves {
+		prs, err := s.prs.ListByReviewerID(ctx, leave.UserID)
+		if err != nil {
+			return err
+		}
+
+		for _, pr := range prs {
+			if pr.IsMerged() {
+				continue
+			}
+
+			_, _, err := s.ReassignReviewer(ctx, pr.ID, leave.UserID, 0)
+			switch {
+			case errors.Is(err, common.ErrNoCandidate), errors.Is(err, common.ErrRulesUnsatisfied),
+				errors.Is(err, common.ErrPRMerged), errors.Is(err, common.ErrNotAssigned):
+				s.log.WarnContext(ctx, "leave: keep review",
+					"leave_id", leave.ID, "pr_id", pr.ID, "reviewer_id", leave.UserID, "error", err)
+			case err != nil:
+				return err
+			}
+		}
+
+		if err := s.users.MarkUnavailabilityHandled(ctx, leave.ID, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
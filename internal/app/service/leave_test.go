@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+func TestPRService_Create_SkipsUnavailable(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	userRepo := newFakeUserRepo()
+	prRepo := newFakePRRepo()
+
+	authorID := uuid.New()
+	onLeave := uuid.New()
+	back := uuid.New()
+	available := uuid.New()
+
+	userRepo.users[authorID] = entity.User{ID: authorID, TeamName: teamName, Name: "Author", IsActive: true}
+	userRepo.users[onLeave] = entity.User{ID: onLeave, TeamName: teamName, Name: "OnLeave", IsActive: true}
+	userRepo.users[back] = entity.User{ID: back, TeamName: teamName, Name: "Back", IsActive: true}
+	userRepo.users[available] = entity.User{ID: available, TeamName: teamName, Name: "Available", IsActive: true}
+
+	leave := entity.Unavailability{ID: uuid.New(), UserID: onLeave, StartsAt: now.Add(-time.Hour), EndsAt: now.Add(24 * time.Hour)}
+	past := entity.Unavailability{ID: uuid.New(), UserID: back, StartsAt: now.Add(-48 * time.Hour), EndsAt: now.Add(-time.Hour)}
+	userRepo.leaves[leave.ID] = leave
+	userRepo.leaves[past.ID] = past
+
+	svc := NewPRService(prRepo, userRepo, newFakeTeamRepo(), fakeTx{}, fakeClock{now: now}, &fakeMetrics{}, discardLogger)
+
+	pr, _, err := svc.Create(ctx, entity.PR{ID: uuid.New(), Title: "PR", AuthorID: authorID})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	if len(pr.Reviewers) != 2 {
+		t.Fatalf("expected 2 reviewers, got %d", len(pr.Reviewers))
+	}
+	for _, rid := range pr.Reviewers {
+		if rid == onLeave {
+			t.Fatalf("user on leave must not be assigned")
+		}
+	}
+}
+
+func TestPRService_ReassignStartedLeaves(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	userRepo := newFakeUserRepo()
+	prRepo := newFakePRRepo()
+
+	authorID := uuid.New()
+	onLeave := uuid.New()
+	other := uuid.New()
+	replacement := uuid.New()
+
+	userRepo.users[authorID] = entity.User{ID: authorID, TeamName: teamName, Name: "Author", IsActive: true}
+	userRepo.users[onLeave] = entity.User{ID: onLeave, TeamName: teamName, Name: "OnLeave", IsActive: true}
+	userRepo.users[other] = entity.User{ID: other, TeamName: teamName, Name: "Other", IsActive: true}
+	userRepo.users[replacement] = entity.User{ID: replacement, TeamName: teamName, Name: "Replacement", IsActive: true}
+	userRepo.reassignOnLeave[teamName] = true
+
+	leave := entity.Unavailability{ID: uuid.New(), UserID: onLeave, StartsAt: now.Add(-time.Minute), EndsAt: now.Add(24 * time.Hour)}
+	userRepo.leaves[leave.ID] = leave
+
+	openID := uuid.New()
+	mergedID := uuid.New()
+	prRepo.prs[openID] = entity.PR{ID: openID, AuthorID: authorID, Status: entity.StatusOpen, Reviewers: []uuid.UUID{onLeave, other}}
+	prRepo.prs[mergedID] = entity.PR{ID: mergedID, AuthorID: authorID, Status: entity.StatusMerged, Reviewers: []uuid.UUID{onLeave}}
+
+	svc := NewPRService(prRepo, userRepo, newFakeTeamRepo(), fakeTx{}, fakeClock{now: now}, &fakeMetrics{}, discardLogger)
+
+	if err := svc.ReassignStartedLeaves(ctx); err != nil {
+		t.Fatalf("ReassignStartedLeaves returned error: %v", err)
+	}
+
+	if got := prRepo.prs[openID].Reviewers; got[0] != replacement || got[1] != other {
+		t.Fatalf("expected open PR reviewers [%s %s], got %v", replacement, other, got)
+	}
+	if got := prRepo.prs[mergedID].Reviewers; got[0] != onLeave {
+		t.Fatalf("merged PR must keep its reviewers, got %v", got)
+	}
+	if userRepo.leaves[leave.ID].HandledAt == nil {
+		t.Fatalf("leave must be marked as handled")
+	}
+}
+
+func TestPRService_ReassignStartedLeaves_RulesUnsatisfied(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	userRepo := newFakeUserRepo()
+	prRepo := newFakePRRepo()
+	teamRepo := newFakeTeamRepo()
+
+	authorID := uuid.New()
+	onLeave := uuid.New()
+	junior := uuid.New()
+
+	userRepo.users[authorID] = entity.User{ID: authorID, TeamName: teamName, Name: "Author", IsActive: true}
+	userRepo.users[onLeave] = entity.User{ID: onLeave, TeamName: teamName, Name: "OnLeave", IsActive: true, IsSenior: true}
+	userRepo.users[junior] = entity.User{ID: junior, TeamName: teamName, Name: "Junior", IsActive: true}
+	userRepo.reassignOnLeave[teamName] = true
+	teamRepo.rules[teamName] = entity.TeamRules{TeamName: teamName, Rules: []entity.TeamRule{{Kind: entity.RuleRequireSenior}}}
+
+	leave := entity.Unavailability{ID: uuid.New(), UserID: onLeave, StartsAt: now.Add(-time.Minute), EndsAt: now.Add(time.Hour)}
+	userRepo.leaves[leave.ID] = leave
+
+	prID := uuid.New()
+	prRepo.prs[prID] = entity.PR{ID: prID, AuthorID: authorID, Status: entity.StatusOpen, Reviewers: []uuid.UUID{onLeave}}
+
+	svc := NewPRService(prRepo, userRepo, teamRepo, fakeTx{}, fakeClock{now: now}, &fakeMetrics{}, discardLogger)
+
+	if err := svc.ReassignStartedLeaves(ctx); err != nil {
+		t.Fatalf("ReassignStartedLeaves returned error: %v", err)
+	}
+
+	if got := prRepo.prs[prID].Reviewers; got[0] != onLeave {
+		t.Fatalf("review must stay when no replacement satisfies the rules, got %v", got)
+	}
+	if userRepo.leaves[leave.ID].HandledAt == nil {
+		t.Fatalf("leave must be marked as handled")
+	}
+}
+
+func TestPRService_ReassignStartedLeaves_TeamDisabled(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	userRepo := newFakeUserRepo()
+	prRepo := newFakePRRepo()
+
+	authorID := uuid.New()
+	onLeave := uuid.New()
+	replacement := uuid.New()
+
+	userRepo.users[authorID] = entity.User{ID: authorID, TeamName: teamName, Name: "Author", IsActive: true}
+	userRepo.users[onLeave] = entity.User{ID: onLeave, TeamName: teamName, Name: "OnLeave", IsActive: true}
+	userRepo.users[replacement] = entity.User{ID: replacement, TeamName: teamName, Name: "Replacement", IsActive: true}
+
+	leave := entity.Unavailability{ID: uuid.New(), UserID: onLeave, StartsAt: now.Add(-time.Minute), EndsAt: now.Add(time.Hour)}
+	userRepo.leaves[leave.ID] = leave
+
+	prID := uuid.New()
+	prRepo.prs[prID] = entity.PR{ID: prID, AuthorID: authorID, Status: entity.StatusOpen, Reviewers: []uuid.UUID{onLeave}}
+
+	svc := NewPRService(prRepo, userRepo, newFakeTeamRepo(), fakeTx{}, fakeClock{now: now}, &fakeMetrics{}, discardLogger)
+
+	if err := svc.ReassignStartedLeaves(ctx); err != nil {
+		t.Fatalf("ReassignStartedLeaves returned error: %v", err)
+	}
+
+	if got := prRepo.prs[prID].Reviewers; got[0] != onLeave {
+		t.Fatalf("reviews must stay when team did not enable reassign on leave, got %v", got)
+	}
+}
@@ -2,21 +2,35 @@ package service
 
 import (
 	"context"
+	"log/slog"
+	"sort"
+	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/Desnn1ch/pr-reviewer-service/internal/app"
 	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
 	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
 )
 
+type fakeClock struct {
+	now time.Time
+}
+
+func (c fakeClock) Now() time.Time { return c.now }
+
 type fakeTx struct{}
 
-func (fakeTx) InTx(ctx context.Context, fn func(context.Context) error) error {
+func (fakeTx) InTx(ctx context.Context, opts app.TxOptions, fn func(context.Context) error) error {
 	return fn(ctx)
 }
 
 type fakeTeamRepo struct {
-	teams map[string]entity.Team
+	teams      map[string]entity.Team
+	codeOwners map[string]entity.CodeOwners
+	rules      map[string]entity.TeamRules
+	settings   map[string]entity.TeamSettings
+	roles      map[string]map[uuid.UUID]entity.TeamRole
 
 	createErr error
 	getErr    error
@@ -24,7 +38,11 @@ type fakeTeamRepo struct {
 
 func newFakeTeamRepo() *fakeTeamRepo {
 	return &fakeTeamRepo{
-		teams: make(map[string]entity.Team),
+		teams:      make(map[string]entity.Team),
+		codeOwners: make(map[string]entity.CodeOwners),
+		rules:      make(map[string]entity.TeamRules),
+		settings:   make(map[string]entity.TeamSettings),
+		roles:      make(map[string]map[uuid.UUID]entity.TeamRole),
 	}
 }
 
@@ -54,8 +72,101 @@ func (r *fakeTeamRepo) GetByName(ctx context.Context, name string) (entity.Team,
 	return t, nil
 }
 
+func (r *fakeTeamRepo) GetCodeOwners(ctx context.Context, teamName string) (entity.CodeOwners, error) {
+	owners, ok := r.codeOwners[teamName]
+	if !ok {
+		return entity.CodeOwners{TeamName: teamName}, nil
+	}
+	return owners, nil
+}
+
+func (r *fakeTeamRepo) ReplaceCodeOwners(ctx context.Context, owners entity.CodeOwners) error {
+	r.codeOwners[owners.TeamName] = owners
+	return nil
+}
+
+func (r *fakeTeamRepo) GetRules(ctx context.Context, teamName string) (entity.TeamRules, error) {
+	rules, ok := r.rules[teamName]
+	if !ok {
+		return entity.TeamRules{TeamName: teamName}, nil
+	}
+	return rules, nil
+}
+
+func (r *fakeTeamRepo) ReplaceRules(ctx context.Context, rules entity.TeamRules) error {
+	r.rules[rules.TeamName] = rules
+	return nil
+}
+
+func (r *fakeTeamRepo) GetSettings(ctx context.Context, teamName string) (entity.TeamSettings, error) {
+	s, ok := r.settings[teamName]
+	if !ok {
+		return entity.DefaultTeamSettings(teamName), nil
+	}
+	return s, nil
+}
+
+func (r *fakeTeamRepo) UpsertSettings(ctx context.Context, s entity.TeamSettings) error {
+	r.settings[s.TeamName] = s
+	return nil
+}
+
+func (r *fakeTeamRepo) ListEscalating(ctx context.Context) ([]entity.TeamSettings, error) {
+	var res []entity.TeamSettings
+	for _, s := range r.settings {
+		if s.EscalateAfter > 0 {
+			res = append(res, s)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].TeamName < res[j].TeamName })
+	return res, nil
+}
+
+// sortUsers orders users like the database does: by name, then id.
+func sortUsers(users []entity.User) {
+	sort.Slice(users, func(i, j int) bool {
+		if users[i].Name != users[j].Name {
+			return users[i].Name < users[j].Name
+		}
+		return users[i].ID.String() < users[j].ID.String()
+	})
+}
+
+func (r *fakeTeamRepo) GetRole(ctx context.Context, teamName string, userID uuid.UUID) (entity.TeamRole, error) {
+	role, ok := r.roles[teamName][userID]
+	if !ok {
+		return entity.RoleNone, common.ErrNotFound
+	}
+	return role, nil
+}
+
+func (r *fakeTeamRepo) SetRole(ctx context.Context, role entity.TeamMemberRole) error {
+	if role.Role == entity.RoleMember {
+		delete(r.roles[role.TeamName], role.UserID)
+		return nil
+	}
+	if r.roles[role.TeamName] == nil {
+		r.roles[role.TeamName] = make(map[uuid.UUID]entity.TeamRole)
+	}
+	r.roles[role.TeamName][role.UserID] = role.Role
+	return nil
+}
+
+func (r *fakeTeamRepo) ListRoles(ctx context.Context, teamName string) ([]entity.TeamMemberRole, error) {
+	var res []entity.TeamMemberRole
+	for id, role := range r.roles[teamName] {
+		res = append(res, entity.TeamMemberRole{TeamName: teamName, UserID: id, Role: role})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].UserID.String() < res[j].UserID.String() })
+	return res, nil
+}
+
 type fakeUserRepo struct {
-	users map[uuid.UUID]entity.User
+	users   map[uuid.UUID]entity.User
+	leaves  map[uuid.UUID]entity.Unavailability
+	changes []entity.ActivityChange
+
+	reassignOnLeave map[string]bool
 
 	getErr    error
 	setErr    error
@@ -66,7 +177,9 @@ type fakeUserRepo struct {
 
 func newFakeUserRepo() *fakeUserRepo {
 	return &fakeUserRepo{
-		users: make(map[uuid.UUID]entity.User),
+		users:           make(map[uuid.UUID]entity.User),
+		leaves:          make(map[uuid.UUID]entity.Unavailability),
+		reassignOnLeave: make(map[string]bool),
 	}
 }
 
@@ -90,6 +203,7 @@ func (r *fakeUserRepo) ListByTeamName(ctx context.Context, teamName string) ([]e
 			res = append(res, u)
 		}
 	}
+	sortUsers(res)
 	return res, nil
 }
 
@@ -100,22 +214,39 @@ func (r *fakeUserRepo) ListActiveByTeamName(ctx context.Context, teamName string
 			res = append(res, u)
 		}
 	}
+	sortUsers(res)
 	return res, nil
 }
 
-func (r *fakeUserRepo) UpsertMany(ctx context.Context, users []entity.User) error {
+func (r *fakeUserRepo) ListActive(ctx context.Context) ([]entity.User, error) {
+	var res []entity.User
+	for _, u := range r.users {
+		if u.IsActive {
+			res = append(res, u)
+		}
+	}
+	sortUsers(res)
+	return res, nil
+}
+
+func (r *fakeUserRepo) UpsertMany(ctx context.Context, users []entity.User, at time.Time) error {
 	if r.upsertErr != nil {
 		return r.upsertErr
 	}
 
 	for _, u := range users {
+		prev, ok := r.users[u.ID]
+		joined := !ok || prev.TeamName != u.TeamName
+		if joined || prev.IsActive != u.IsActive {
+			r.changes = append(r.changes, entity.ActivityChange{UserID: u.ID, IsActive: u.IsActive, Joined: joined, ChangedAt: at})
+		}
 		r.users[u.ID] = u
 	}
 
 	return nil
 }
 
-func (r *fakeUserRepo) SetActive(ctx context.Context, id uuid.UUID, active bool) error {
+func (r *fakeUserRepo) SetActive(ctx context.Context, id uuid.UUID, active bool, at time.Time) error {
 	r.setCalls++
 
 	if r.setErr != nil {
@@ -129,11 +260,151 @@ func (r *fakeUserRepo) SetActive(ctx context.Context, id uuid.UUID, active bool)
 
 	u.IsActive = active
 	r.users[id] = u
+	r.changes = append(r.changes, entity.ActivityChange{UserID: id, IsActive: active, ChangedAt: at})
+	return nil
+}
+
+func (r *fakeUserRepo) SetSkills(ctx context.Context, id uuid.UUID, skills []string) error {
+	u, ok := r.users[id]
+	if !ok {
+		return common.ErrNotFound
+	}
+
+	u.Skills = skills
+	r.users[id] = u
+	return nil
+}
+
+func (r *fakeUserRepo) SetWorkingHours(ctx context.Context, id uuid.UUID, wh *entity.WorkingHours) error {
+	u, ok := r.users[id]
+	if !ok {
+		return common.ErrNotFound
+	}
+
+	u.WorkingHours = wh
+	r.users[id] = u
+	return nil
+}
+
+func (r *fakeUserRepo) SetMaxOpenReviews(ctx context.Context, id uuid.UUID, limit *int) error {
+	u, ok := r.users[id]
+	if !ok {
+		return common.ErrNotFound
+	}
+
+	u.MaxOpenReviews = limit
+	r.users[id] = u
+	return nil
+}
+
+func (r *fakeUserRepo) CreateUnavailability(ctx context.Context, u entity.Unavailability) error {
+	r.leaves[u.ID] = u
+	return nil
+}
+
+func (r *fakeUserRepo) GetUnavailability(ctx context.Context, id uuid.UUID) (entity.Unavailability, error) {
+	u, ok := r.leaves[id]
+	if !ok {
+		return entity.Unavailability{}, common.ErrNotFound
+	}
+	return u, nil
+}
+
+func (r *fakeUserRepo) UpdateUnavailability(ctx context.Context, u entity.Unavailability) error {
+	if _, ok := r.leaves[u.ID]; !ok {
+		return common.ErrNotFound
+	}
+	r.leaves[u.ID] = u
+	return nil
+}
+
+func (r *fakeUserRepo) DeleteUnavailability(ctx context.Context, id uuid.UUID) error {
+	if _, ok := r.leaves[id]; !ok {
+		return common.ErrNotFound
+	}
+	delete(r.leaves, id)
+	return nil
+}
+
+func (r *fakeUserRepo) ListUnavailability(ctx context.Context, userID uuid.UUID) ([]entity.Unavailability, error) {
+	var res []entity.Unavailability
+	for _, u := range r.leaves {
+		if u.UserID == userID {
+			res = append(res, u)
+		}
+	}
+	return res, nil
+}
+
+func (r *fakeUserRepo) ListUnavailableUserIDs(ctx context.Context, teamName string, at time.Time) ([]uuid.UUID, error) {
+	var res []uuid.UUID
+	for _, u := range r.leaves {
+		if r.users[u.UserID].TeamName == teamName && u.Covers(at) {
+			res = append(res, u.UserID)
+		}
+	}
+	return res, nil
+}
+
+func (r *fakeUserRepo) ListStartedLeaves(ctx context.Context, at time.Time) ([]entity.Unavailability, error) {
+	var res []entity.Unavailability
+	for _, u := range r.leaves {
+		if u.HandledAt == nil && u.Covers(at) && r.reassignOnLeave[r.users[u.UserID].TeamName] {
+			res = append(res, u)
+		}
+	}
+	return res, nil
+}
+
+func (r *fakeUserRepo) ListTeamUnavailability(ctx context.Context, teamName string, from, to time.Time) ([]entity.Unavailability, error) {
+	var res []entity.Unavailability
+	for _, u := range r.leaves {
+		if r.users[u.UserID].TeamName == teamName && u.StartsAt.Before(to) && u.EndsAt.After(from) {
+			res = append(res, u)
+		}
+	}
+	return res, nil
+}
+
+func (r *fakeUserRepo) ListActivityChanges(ctx context.Context, teamName string, before time.Time) ([]entity.ActivityChange, error) {
+	var res []entity.ActivityChange
+	firstAfter := make(map[uuid.UUID]entity.ActivityChange)
+	for _, c := range r.changes {
+		if r.users[c.UserID].TeamName != teamName {
+			continue
+		}
+		if c.ChangedAt.Before(before) {
+			res = append(res, c)
+			continue
+		}
+		if c.Joined {
+			continue
+		}
+		if f, ok := firstAfter[c.UserID]; !ok || c.ChangedAt.Before(f.ChangedAt) {
+			firstAfter[c.UserID] = c
+		}
+	}
+	for _, c := range firstAfter {
+		res = append(res, c)
+	}
+	return res, nil
+}
+
+func (r *fakeUserRepo) MarkUnavailabilityHandled(ctx context.Context, id uuid.UUID, at time.Time) error {
+	u := r.leaves[id]
+	u.HandledAt = &at
+	r.leaves[id] = u
 	return nil
 }
 
 type fakePRRepo struct {
-	prs map[uuid.UUID]entity.PR
+	prs       map[uuid.UUID]entity.PR
+	decisions []entity.AssignmentDecision
+	events    []entity.PREvent
+
+	reviewerStats []entity.ReviewerStats
+	// authorTeams maps authors to their team for CountOpenByTeam.
+	authorTeams map[uuid.UUID]string
 
 	existsErr error
 	createErr error
@@ -182,15 +453,24 @@ func (r *fakePRRepo) GetByID(ctx context.Context, id uuid.UUID) (entity.PR, erro
 	return pr, nil
 }
 
+func (r *fakePRRepo) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (entity.PR, error) {
+	return r.GetByID(ctx, id)
+}
+
 func (r *fakePRRepo) Update(ctx context.Context, pr entity.PR) error {
 	if r.updateErr != nil {
 		return r.updateErr
 	}
 
-	if _, ok := r.prs[pr.ID]; !ok {
+	stored, ok := r.prs[pr.ID]
+	if !ok {
 		return common.ErrNotFound
 	}
+	if stored.Version != pr.Version {
+		return common.ErrConflict
+	}
 
+	pr.Version++
 	r.prs[pr.ID] = pr
 	return nil
 }
@@ -214,7 +494,351 @@ func (r *fakePRRepo) ListByReviewerID(ctx context.Context, reviewerID uuid.UUID)
 	return res, nil
 }
 
-func (r *fakePRRepo) ListReviewerStats(ctx context.Context, teamName string) ([]entity.ReviewerStats, error) {
-	// for unit test stats - over
-	return nil, nil
+func (r *fakePRRepo) CountOpenReviews(ctx context.Context, teamName string) (map[uuid.UUID]int, error) {
+	res := make(map[uuid.UUID]int)
+	for _, pr := range r.prs {
+		if pr.IsMerged() {
+			continue
+		}
+		for _, rid := range pr.Reviewers {
+			res[rid]++
+		}
+	}
+	return res, nil
+}
+
+func (r *fakePRRepo) ListOpenAssignments(ctx context.Context, teamName string, assignedBefore time.Time) ([]entity.ReviewAssignment, error) {
+	var res []entity.ReviewAssignment
+	for _, pr := range r.prs {
+		if pr.IsMerged() {
+			continue
+		}
+		for _, rid := range pr.Reviewers {
+			at := pr.ReviewerAssignedAt(rid)
+			if at.After(assignedBefore) || r.reviewedAt(pr.ID, rid, at) != nil {
+				continue
+			}
+			res = append(res, entity.ReviewAssignment{PRID: pr.ID, Title: pr.Title, AuthorID: pr.AuthorID, ReviewerID: rid, AssignedAt: at})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].AssignedAt.Before(res[j].AssignedAt) })
+	return res, nil
+}
+
+func (r *fakePRRepo) CreateDecision(ctx context.Context, d entity.AssignmentDecision) error {
+	r.decisions = append(r.decisions, d)
+	return nil
+}
+
+func (r *fakePRRepo) ListDecisions(ctx context.Context, prID uuid.UUID) ([]entity.AssignmentDecision, error) {
+	var res []entity.AssignmentDecision
+	for _, d := range r.decisions {
+		if d.PRID == prID {
+			res = append(res, d)
+		}
+	}
+	return res, nil
+}
+
+func (r *fakePRRepo) CreateEvent(ctx context.Context, ev entity.PREvent) error {
+	r.events = append(r.events, ev)
+	return nil
+}
+
+func (r *fakePRRepo) ListEvents(ctx context.Context, prID uuid.UUID) ([]entity.PREvent, error) {
+	var res []entity.PREvent
+	for _, ev := range r.events {
+		if ev.PRID == prID {
+			res = append(res, ev)
+		}
+	}
+	return res, nil
+}
+
+// reviewedAt returns the first review of the assignment made at or after assignedAt.
+func (r *fakePRRepo) reviewedAt(prID, reviewerID uuid.UUID, assignedAt time.Time) *time.Time {
+	var first *time.Time
+	for _, ev := range r.events {
+		if ev.PRID != prID || ev.ReviewerID != reviewerID || ev.Kind != entity.EventReviewed || ev.CreatedAt.Before(assignedAt) {
+			continue
+		}
+		if first == nil || ev.CreatedAt.Before(*first) {
+			at := ev.CreatedAt
+			first = &at
+		}
+	}
+	return first
+}
+
+func (r *fakePRRepo) ListTeamPRTimes(ctx context.Context, teamName string, from, to time.Time) ([]entity.PRTimes, error) {
+	in := func(t time.Time) bool { return !t.Before(from) && t.Before(to) }
+
+	var res []entity.PRTimes
+	for _, pr := range r.prs {
+		if in(pr.CreatedAt) || (pr.MergedAt != nil && in(*pr.MergedAt)) {
+			res = append(res, entity.PRTimes{PRID: pr.ID, CreatedAt: pr.CreatedAt, MergedAt: pr.MergedAt})
+		}
+	}
+	return res, nil
+}
+
+func (r *fakePRRepo) ListFirstReviews(ctx context.Context, teamName string, from, to time.Time) ([]entity.FirstReview, error) {
+	type key struct{ pr, reviewer uuid.UUID }
+
+	first := make(map[key]time.Time)
+	for _, ev := range r.events {
+		k := key{ev.PRID, ev.ReviewerID}
+		if at, ok := first[k]; ev.Kind == entity.EventReviewed && (!ok || ev.CreatedAt.Before(at)) {
+			first[k] = ev.CreatedAt
+		}
+	}
+
+	var res []entity.FirstReview
+	for k, reviewedAt := range first {
+		if reviewedAt.Before(from) || !reviewedAt.Before(to) {
+			continue
+		}
+
+		var assigned []time.Time
+		if pr, ok := r.prs[k.pr]; ok && pr.HasReviewer(k.reviewer) {
+			assigned = append(assigned, pr.ReviewerAssignedAt(k.reviewer))
+		}
+		for _, d := range r.decisions {
+			for _, c := range d.Chosen {
+				if d.PRID == k.pr && c.UserID == k.reviewer {
+					assigned = append(assigned, d.CreatedAt)
+				}
+			}
+		}
+		for _, ev := range r.events {
+			if ev.PRID == k.pr && ev.Kind == entity.EventEscalationLeadAdded && ev.NewReviewerID == k.reviewer {
+				assigned = append(assigned, ev.CreatedAt)
+			}
+		}
+
+		var assignedAt *time.Time
+		for _, at := range assigned {
+			if !at.After(reviewedAt) && (assignedAt == nil || at.After(*assignedAt)) {
+				assignedAt = &at
+			}
+		}
+		if assignedAt == nil {
+			continue
+		}
+		res = append(res, entity.FirstReview{ReviewerID: k.reviewer, PRID: k.pr, AssignedAt: *assignedAt, ReviewedAt: reviewedAt})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].ReviewerID != res[j].ReviewerID {
+			return res[i].ReviewerID.String() < res[j].ReviewerID.String()
+		}
+		return res[i].ReviewedAt.Before(res[j].ReviewedAt)
+	})
+	return res, nil
+}
+
+func (r *fakePRRepo) CountAssignments(ctx context.Context, teamName string, from, to time.Time) (map[uuid.UUID]int, error) {
+	in := func(t time.Time) bool { return !t.Before(from) && t.Before(to) }
+
+	res := make(map[uuid.UUID]int)
+	for _, d := range r.decisions {
+		if !in(d.CreatedAt) {
+			continue
+		}
+		for _, c := range d.Chosen {
+			res[c.UserID]++
+		}
+	}
+	for _, ev := range r.events {
+		if ev.Kind == entity.EventEscalationLeadAdded && in(ev.CreatedAt) {
+			res[ev.NewReviewerID]++
+		}
+	}
+	return res, nil
+}
+
+func (r *fakePRRepo) ListReviewerStats(ctx context.Context, teamName string, includeInactive bool) ([]entity.ReviewerStats, error) {
+	var res []entity.ReviewerStats
+	for _, s := range r.reviewerStats {
+		if s.TeamName == teamName && (s.IsActive || includeInactive) {
+			res = append(res, s)
+		}
+	}
+	return res, nil
+}
+
+func (r *fakePRRepo) GetReviewTotals(ctx context.Context, userID uuid.UUID) (entity.ReviewTotals, error) {
+	var (
+		t     entity.ReviewTotals
+		total time.Duration
+		n     int
+	)
+	for _, pr := range r.prs {
+		if !pr.HasReviewer(userID) {
+			continue
+		}
+		t.TotalAssignments++
+		if pr.IsMerged() {
+			total += pr.MergedAt.Sub(pr.CreatedAt)
+			n++
+		} else {
+			t.OpenAssignments++
+		}
+	}
+	for _, d := range r.decisions {
+		if d.Kind == entity.AssignmentReassign && d.ReplacedUserID == userID {
+			t.ReassignedAway++
+		}
+	}
+	t.TotalAssignments += t.ReassignedAway
+	if n > 0 {
+		avg := total / time.Duration(n)
+		t.AvgOpenTime = &avg
+	}
+	return t, nil
+}
+
+func (r *fakePRRepo) GetAuthorTotals(ctx context.Context, userID uuid.UUID) (entity.AuthorTotals, error) {
+	var t entity.AuthorTotals
+	for _, pr := range r.prs {
+		if pr.AuthorID != userID {
+			continue
+		}
+		t.Authored++
+		if pr.IsMerged() {
+			t.Merged++
+		}
+	}
+	return t, nil
+}
+
+func (r *fakePRRepo) CountOpenByTeam(ctx context.Context) (map[string]int, error) {
+	res := make(map[string]int)
+	for _, pr := range r.prs {
+		if !pr.IsMerged() {
+			res[r.authorTeams[pr.AuthorID]]++
+		}
+	}
+	return res, nil
+}
+
+var discardLogger = slog.New(slog.DiscardHandler)
+
+type fakeMetrics struct {
+	created     int
+	merged      int
+	reassigned  int
+	noCandidate int
+	openPRs     map[string]int
+}
+
+func (m *fakeMetrics) PRCreated()          { m.created++ }
+func (m *fakeMetrics) PRMerged()           { m.merged++ }
+func (m *fakeMetrics) ReviewerReassigned() { m.reassigned++ }
+func (m *fakeMetrics) NoCandidate()        { m.noCandidate++ }
+
+func (m *fakeMetrics) SetOpenPRs(teamName string, n int) {
+	if m.openPRs == nil {
+		m.openPRs = make(map[string]int)
+	}
+	m.openPRs[teamName] = n
+}
+
+type fakeNotifier struct {
+	sent []entity.Notification
+	err  error
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, notif entity.Notification) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.sent = append(n.sent, notif)
+	return nil
+}
+
+type fakeAPIKeyRepo struct {
+	keys map[uuid.UUID]entity.APIKey
+}
+
+func newFakeAPIKeyRepo() *fakeAPIKeyRepo {
+	return &fakeAPIKeyRepo{keys: make(map[uuid.UUID]entity.APIKey)}
+}
+
+func (r *fakeAPIKeyRepo) Create(ctx context.Context, key entity.APIKey) error {
+	r.keys[key.ID] = key
+	return nil
+}
+
+func (r *fakeAPIKeyRepo) GetByHash(ctx context.Context, hash string) (entity.APIKey, error) {
+	for _, k := range r.keys {
+		if k.Hash == hash {
+			return k, nil
+		}
+	}
+	return entity.APIKey{}, common.ErrNotFound
+}
+
+func (r *fakeAPIKeyRepo) List(ctx context.Context) ([]entity.APIKey, error) {
+	res := make([]entity.APIKey, 0, len(r.keys))
+	for _, k := range r.keys {
+		res = append(res, k)
+	}
+	return res, nil
+}
+
+func (r *fakeAPIKeyRepo) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	k, ok := r.keys[id]
+	if !ok || k.RevokedAt != nil {
+		return common.ErrNotFound
+	}
+	k.RevokedAt = &at
+	r.keys[id] = k
+	return nil
+}
+
+type fakeIdempotencyRepo struct {
+	records map[string]entity.IdempotencyRecord
+}
+
+func newFakeIdempotencyRepo() *fakeIdempotencyRepo {
+	return &fakeIdempotencyRepo{records: make(map[string]entity.IdempotencyRecord)}
+}
+
+func (r *fakeIdempotencyRepo) Reserve(ctx context.Context, rec entity.IdempotencyRecord) (entity.IdempotencyRecord, bool, error) {
+	id := rec.Client + "|" + rec.Key
+	if stored, ok := r.records[id]; ok && stored.ExpiresAt.After(rec.CreatedAt) {
+		return stored, false, nil
+	}
+	r.records[id] = rec
+	return rec, true, nil
+}
+
+func (r *fakeIdempotencyRepo) Complete(ctx context.Context, rec entity.IdempotencyRecord) error {
+	id := rec.Client + "|" + rec.Key
+	stored, ok := r.records[id]
+	if !ok {
+		return nil
+	}
+	stored.StatusCode = rec.StatusCode
+	stored.ContentType = rec.ContentType
+	stored.ETag = rec.ETag
+	stored.Body = rec.Body
+	r.records[id] = stored
+	return nil
+}
+
+func (r *fakeIdempotencyRepo) Delete(ctx context.Context, client, key string) error {
+	delete(r.records, client+"|"+key)
+	return nil
+}
+
+func (r *fakeIdempotencyRepo) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	var n int64
+	for id, rec := range r.records {
+		if !rec.ExpiresAt.After(before) {
+			delete(r.records, id)
+			n++
+		}
+	}
+	return n, nil
 }
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/app"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+type NotificationService struct {
+	prs      app.PRRepo
+	users    app.UserRepo
+	notifier app.Notifier
+	clock    common.Clock
+	log      *slog.Logger
+}
+
+func NewNotificationService(prs app.PRRepo, users app.UserRepo, notifier app.Notifier, clock common.Clock, log *slog.Logger) *NotificationService {
+	return &NotificationService{
+		prs:      prs,
+		users:    users,
+		notifier: notifier,
+		clock:    clock,
+		log:      log,
+	}
+}
+
+// SendDigests notifies every active user about the open PRs waiting for their review.
+// Users without pending reviews get nothing; a failed delivery does not stop the others.
+func (s *NotificationService) SendDigests(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "NotificationService.SendDigests")
+	defer span.End()
+
+	users, err := s.users.ListActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := s.clock.Now()
+
+	waiting := make(map[uuid.UUID][]entity.ReviewAssignment)
+	listed := make(map[string]bool)
+	for _, u := range users {
+		if listed[u.TeamName] {
+			continue
+		}
+		listed[u.TeamName] = true
+
+		assignments, err := s.prs.ListOpenAssignments(ctx, u.TeamName, now)
+		if err != nil {
+			return err
+		}
+		for _, a := range assignments {
+			waiting[a.ReviewerID] = append(waiting[a.ReviewerID], a)
+		}
+	}
+
+	failed := 0
+
+	for _, u := range users {
+		open := waiting[u.ID]
+		if len(open) == 0 {
+			continue
+		}
+
+		if err := s.notifier.Notify(ctx, entity.NewDigest(u, open, now)); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			s.log.WarnContext(ctx, "digest not sent", "user_id", u.ID, "error", err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d digest(s) failed to send", failed)
+	}
+
+	return nil
+}
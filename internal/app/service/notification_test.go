@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+func TestNotificationService_SendDigests(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+
+	userRepo := newFakeUserRepo()
+	prRepo := newFakePRRepo()
+	notifier := &fakeNotifier{}
+
+	authorID := uuid.New()
+	busy := uuid.New()
+	idle := uuid.New()
+	inactive := uuid.New()
+
+	userRepo.users[authorID] = entity.User{ID: authorID, TeamName: teamName, Name: "Author", IsActive: true}
+	userRepo.users[busy] = entity.User{ID: busy, TeamName: teamName, Name: "Busy", IsActive: true, Email: "busy@example.com"}
+	userRepo.users[idle] = entity.User{ID: idle, TeamName: teamName, Name: "Idle", IsActive: true}
+	userRepo.users[inactive] = entity.User{ID: inactive, TeamName: teamName, Name: "Inactive", IsActive: false}
+
+	older := entity.PR{ID: uuid.New(), Title: "Older", AuthorID: authorID, Status: entity.StatusOpen, CreatedAt: now.Add(-48 * time.Hour), Reviewers: []uuid.UUID{busy, inactive}}
+	newer := entity.PR{ID: uuid.New(), Title: "Newer", AuthorID: authorID, Status: entity.StatusOpen, CreatedAt: now.Add(-time.Hour), Reviewers: []uuid.UUID{busy}}
+	merged := entity.PR{ID: uuid.New(), Title: "Merged", AuthorID: authorID, Status: entity.StatusMerged, CreatedAt: now.Add(-time.Hour), Reviewers: []uuid.UUID{busy, idle}}
+	reviewed := entity.PR{ID: uuid.New(), Title: "Reviewed", AuthorID: authorID, Status: entity.StatusOpen, CreatedAt: now.Add(-3 * time.Hour), Reviewers: []uuid.UUID{busy}}
+	for _, pr := range []entity.PR{newer, older, merged, reviewed} {
+		prRepo.prs[pr.ID] = pr
+	}
+	prRepo.events = []entity.PREvent{
+		{ID: uuid.New(), PRID: reviewed.ID, Kind: entity.EventReviewed, ReviewerID: busy, CreatedAt: now.Add(-2 * time.Hour)},
+	}
+
+	svc := NewNotificationService(prRepo, userRepo, notifier, fakeClock{now: now}, discardLogger)
+
+	if err := svc.SendDigests(ctx); err != nil {
+		t.Fatalf("SendDigests returned error: %v", err)
+	}
+
+	if len(notifier.sent) != 1 {
+		t.Fatalf("expected a single digest, got %d", len(notifier.sent))
+	}
+
+	d := notifier.sent[0]
+	if d.Kind != entity.NotificationDigest || d.Recipient.ID != busy {
+		t.Fatalf("unexpected digest %+v", d)
+	}
+	if len(d.PullRequests) != 2 || d.PullRequests[0].PRID != older.ID || d.PullRequests[1].PRID != newer.ID {
+		t.Fatalf("expected unreviewed open PRs oldest first, got %+v", d.PullRequests)
+	}
+
+	notifier.err = errors.New("smtp down")
+	if err := svc.SendDigests(ctx); err == nil {
+		t.Fatalf("expected an error when deliveries fail")
+	}
+}
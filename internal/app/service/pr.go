@@ -2,6 +2,9 @@ package service
 
 import (
 	"context"
+	"errors"
+	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -11,73 +14,132 @@ import (
 )
 
 type PRService struct {
-	prs   app.PRRepo
-	users app.UserRepo
-	tx    app.TxManager
-	clock common.Clock
+	prs     app.PRRepo
+	users   app.UserRepo
+	teams   app.TeamRepo
+	tx      app.TxManager
+	clock   common.Clock
+	metrics app.Metrics
+	log     *slog.Logger
 }
 
-func NewPRService(prs app.PRRepo, users app.UserRepo, tx app.TxManager, clock common.Clock) *PRService {
+// selectionTx isolates transactions choosing reviewers from concurrent ones: the choice depends on
+// the open review load of the whole team, which row locks on the PR alone do not protect.
+var selectionTx = app.TxOptions{Isolation: app.IsolationSerializable}
+
+func NewPRService(prs app.PRRepo, users app.UserRepo, teams app.TeamRepo, tx app.TxManager, clock common.Clock, metrics app.Metrics, log *slog.Logger) *PRService {
 	return &PRService{
-		prs:   prs,
-		users: users,
-		tx:    tx,
-		clock: clock,
+		prs:     prs,
+		users:   users,
+		teams:   teams,
+		tx:      tx,
+		clock:   clock,
+		metrics: metrics,
+		log:     log,
 	}
 }
 
-func (s *PRService) Create(ctx context.Context, id uuid.UUID, title string, authorID uuid.UUID) (entity.PR, error) {
-	author, err := s.users.GetByID(ctx, authorID)
-	if err != nil {
-		return entity.PR{}, err
-	}
+func (s *PRService) Create(ctx context.Context, draft entity.PR) (entity.PR, []entity.ReviewerChoice, error) {
+	ctx, span := tracer.Start(ctx, "PRService.Create")
+	defer span.End()
 
-	activeUsers, err := s.users.ListActiveByTeamName(ctx, author.TeamName)
+	author, err := s.users.GetByID(ctx, draft.AuthorID)
 	if err != nil {
-		return entity.PR{}, err
+		return entity.PR{}, nil, err
 	}
 
-	candidates := make([]uuid.UUID, 0, len(activeUsers))
-	for _, u := range activeUsers {
-		if u.ID == author.ID {
-			continue
+	requiredTags := entity.NormalizeTags(draft.RequiredTags)
+
+	var (
+		pr       entity.PR
+		decision entity.AssignmentDecision
+	)
+
+	// Reviewers are chosen in the same transaction that stores them, so that concurrent creates
+	// see each other's assignments.
+	err = s.tx.InTx(ctx, selectionTx, func(txCtx context.Context) error {
+		var err error
+		decision, err = s.selectReviewers(txCtx, selection{
+			teamName:     author.TeamName,
+			authorID:     author.ID,
+			changedFiles: draft.ChangedFiles,
+			requiredTags: requiredTags,
+			slots:        maxReviewers,
+		})
+		if err != nil {
+			return err
 		}
-		candidates = append(candidates, u.ID)
-	}
 
-	reviewers := make([]uuid.UUID, 0, 2)
-	for i := 0; i < len(candidates) && i < 2; i++ {
-		reviewers = append(reviewers, candidates[i])
-	}
+		now := s.clock.Now()
 
-	pr := entity.PR{
-		ID:        id,
-		Title:     title,
-		AuthorID:  authorID,
-		Status:    entity.StatusOpen,
-		CreatedAt: s.clock.Now(),
-		Reviewers: reviewers,
-	}
+		reviewers := make([]uuid.UUID, 0, len(decision.Chosen))
+		assignedAt := make(map[uuid.UUID]time.Time, len(decision.Chosen))
+		for _, c := range decision.Chosen {
+			reviewers = append(reviewers, c.UserID)
+			assignedAt[c.UserID] = now
+		}
+
+		pr = entity.PR{
+			ID:           draft.ID,
+			Title:        draft.Title,
+			AuthorID:     author.ID,
+			Status:       entity.StatusOpen,
+			CreatedAt:    now,
+			ChangedFiles: draft.ChangedFiles,
+			RequiredTags: requiredTags,
+			Reviewers:    reviewers,
+			AssignedAt:   assignedAt,
+			Version:      1,
+		}
+
+		decision.ID = uuid.New()
+		decision.PRID = pr.ID
+		decision.Kind = entity.AssignmentCreate
+		decision.CreatedAt = pr.CreatedAt
 
-	err = s.tx.InTx(ctx, func(txCtx context.Context) error {
-		return s.prs.Create(txCtx, pr)
+		if err := s.prs.Create(txCtx, pr); err != nil {
+			return err
+		}
+		return s.prs.CreateDecision(txCtx, decision)
 	})
 	if err != nil {
-		return entity.PR{}, err
+		s.countNoCandidate(err)
+		return entity.PR{}, nil, err
 	}
 
-	return pr, nil
+	s.metrics.PRCreated()
+
+	return pr, decision.Chosen, nil
 }
 
-func (s *PRService) Merge(ctx context.Context, id uuid.UUID) (entity.PR, error) {
-	var result entity.PR
+// Merge merges the PR; merging a merged PR returns it unchanged. A non-zero version must be the
+// PR's current one, otherwise the merge fails with ErrConflict.
+func (s *PRService) Merge(ctx context.Context, id uuid.UUID, version int64) (entity.PR, error) {
+	ctx, span := tracer.Start(ctx, "PRService.Merge")
+	defer span.End()
+
+	var (
+		result entity.PR
+		merged bool
+	)
 
-	err := s.tx.InTx(ctx, func(txCtx context.Context) error {
-		pr, err := s.prs.GetByID(txCtx, id)
+	err := s.tx.InTx(ctx, app.TxOptions{}, func(txCtx context.Context) error {
+		// The transaction may be retried; only the attempt that commits counts.
+		merged = false
+
+		pr, err := s.prs.GetByIDForUpdate(txCtx, id)
 		if err != nil {
 			return err
 		}
 
+		if err := s.authorizePRActor(txCtx, pr, pr.AuthorID); err != nil {
+			return err
+		}
+
+		if !pr.MatchesVersion(version) {
+			return common.ErrConflict
+		}
+
 		if pr.IsMerged() {
 			result = pr
 			return nil
@@ -90,8 +152,9 @@ func (s *PRService) Merge(ctx context.Context, id uuid.UUID) (entity.PR, error)
 		if err := s.prs.Update(txCtx, pr); err != nil {
 			return err
 		}
+		pr.Version++
 
-		result = pr
+		result, merged = pr, true
 		return nil
 	})
 
@@ -99,80 +162,244 @@ func (s *PRService) Merge(ctx context.Context, id uuid.UUID) (entity.PR, error)
 		return entity.PR{}, err
 	}
 
+	if merged {
+		s.metrics.PRMerged()
+	}
+
 	return result, nil
 }
 
-func (s *PRService) ReassignReviewer(ctx context.Context, prID, oldReviewerID uuid.UUID) (entity.PR, error) {
+// ReassignReviewer replaces oldReviewerID with another reviewer. A non-zero version must be the
+// PR's current one, otherwise it fails with ErrConflict.
+func (s *PRService) ReassignReviewer(ctx context.Context, prID, oldReviewerID uuid.UUID, version int64) (entity.PR, entity.ReviewerChoice, error) {
+	ctx, span := tracer.Start(ctx, "PRService.ReassignReviewer")
+	defer span.End()
+
 	var result entity.PR
+	var choice entity.ReviewerChoice
 
-	err := s.tx.InTx(ctx, func(txCtx context.Context) error {
-		pr, err := s.prs.GetByID(txCtx, prID)
-		if err != nil {
-			return err
-		}
+	err := s.tx.InTx(ctx, selectionTx, func(txCtx context.Context) error {
+		var err error
+		result, choice, err = s.reassign(txCtx, prID, oldReviewerID, version)
+		return err
+	})
+	if err != nil {
+		s.countNoCandidate(err)
+		return entity.PR{}, entity.ReviewerChoice{}, err
+	}
 
-		if pr.IsMerged() {
-			return common.ErrPRMerged
+	s.metrics.ReviewerReassigned()
+
+	return result, choice, nil
+}
+
+func (s *PRService) countNoCandidate(err error) {
+	if errors.Is(err, common.ErrNoCandidate) {
+		s.metrics.NoCandidate()
+	}
+}
+
+// RefreshOpenPRMetrics updates the open PR gauge of every team.
+func (s *PRService) RefreshOpenPRMetrics(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "PRService.RefreshOpenPRMetrics")
+	defer span.End()
+
+	counts, err := s.prs.CountOpenByTeam(ctx)
+	if err != nil {
+		return err
+	}
+
+	for team, n := range counts {
+		s.metrics.SetOpenPRs(team, n)
+	}
+
+	return nil
+}
+
+// reassign replaces oldReviewerID on the PR and records the decision; it must run inside a
+// selectionTx transaction.
+func (s *PRService) reassign(txCtx context.Context, prID, oldReviewerID uuid.UUID, version int64) (entity.PR, entity.ReviewerChoice, error) {
+	pr, err := s.prs.GetByIDForUpdate(txCtx, prID)
+	if err != nil {
+		return entity.PR{}, entity.ReviewerChoice{}, err
+	}
+
+	if err := s.authorizePRActor(txCtx, pr, oldReviewerID); err != nil {
+		return entity.PR{}, entity.ReviewerChoice{}, err
+	}
+
+	if !pr.MatchesVersion(version) {
+		return entity.PR{}, entity.ReviewerChoice{}, common.ErrConflict
+	}
+
+	if pr.IsMerged() {
+		return entity.PR{}, entity.ReviewerChoice{}, common.ErrPRMerged
+	}
+
+	idx := -1
+	for i, r := range pr.Reviewers {
+		if r == oldReviewerID {
+			idx = i
+			break
 		}
+	}
+	if idx == -1 {
+		return entity.PR{}, entity.ReviewerChoice{}, common.ErrNotAssigned
+	}
+
+	oldReviewer, err := s.users.GetByID(txCtx, oldReviewerID)
+	if err != nil {
+		return entity.PR{}, entity.ReviewerChoice{}, err
+	}
 
-		idx := -1
-		for i, r := range pr.Reviewers {
-			if r == oldReviewerID {
-				idx = i
-				break
-			}
+	keep := make([]uuid.UUID, 0, len(pr.Reviewers)-1)
+	for _, r := range pr.Reviewers {
+		if r != oldReviewerID {
+			keep = append(keep, r)
 		}
-		if idx == -1 {
-			return common.ErrNotAssigned
+	}
+
+	decision, err := s.selectReviewers(txCtx, selection{
+		teamName:     oldReviewer.TeamName,
+		authorID:     pr.AuthorID,
+		changedFiles: pr.ChangedFiles,
+		requiredTags: pr.RequiredTags,
+		keep:         keep,
+		exclude:      []uuid.UUID{oldReviewerID},
+		slots:        1,
+	})
+	if err != nil {
+		return entity.PR{}, entity.ReviewerChoice{}, err
+	}
+
+	if len(decision.Chosen) == 0 {
+		return entity.PR{}, entity.ReviewerChoice{}, common.ErrNoCandidate
+	}
+
+	now := s.clock.Now()
+	newReviewerID := decision.Chosen[0].UserID
+
+	pr.Reviewers[idx] = newReviewerID
+	if pr.AssignedAt == nil {
+		pr.AssignedAt = make(map[uuid.UUID]time.Time)
+	}
+	delete(pr.AssignedAt, oldReviewerID)
+	pr.AssignedAt[newReviewerID] = now
+
+	if err := s.prs.Update(txCtx, pr); err != nil {
+		return entity.PR{}, entity.ReviewerChoice{}, err
+	}
+	pr.Version++
+
+	decision.ID = uuid.New()
+	decision.PRID = pr.ID
+	decision.Kind = entity.AssignmentReassign
+	decision.ReplacedUserID = oldReviewerID
+	decision.CreatedAt = now
+
+	if err := s.prs.CreateDecision(txCtx, decision); err != nil {
+		return entity.PR{}, entity.ReviewerChoice{}, err
+	}
+
+	return pr, decision.Chosen[0], nil
+}
+
+func (s *PRService) ExplainAssignment(ctx context.Context, prID uuid.UUID) ([]entity.AssignmentDecision, error) {
+	ctx, span := tracer.Start(ctx, "PRService.ExplainAssignment")
+	defer span.End()
+
+	if _, err := s.prs.GetByID(ctx, prID); err != nil {
+		return nil, err
+	}
+
+	return s.prs.ListDecisions(ctx, prID)
+}
+
+// ListOverdue returns reviews of the team's members waiting longer than the team review SLA, oldest first.
+func (s *PRService) ListOverdue(ctx context.Context, teamName string) ([]entity.OverdueReview, error) {
+	ctx, span := tracer.Start(ctx, "PRService.ListOverdue")
+	defer span.End()
+
+	if _, err := s.teams.GetByName(ctx, teamName); err != nil {
+		return nil, err
+	}
+
+	settings, err := s.teams.GetSettings(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if settings.ReviewSLA == 0 {
+		return nil, nil
+	}
+
+	now := s.clock.Now()
+
+	assignments, err := s.prs.ListOpenAssignments(ctx, teamName, now.Add(-settings.ReviewSLA))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]entity.OverdueReview, 0, len(assignments))
+	for _, a := range assignments {
+		age := a.Age(now)
+		if age <= settings.ReviewSLA {
+			continue
 		}
+		res = append(res, entity.OverdueReview{Assignment: a, Age: age, SLA: settings.ReviewSLA})
+	}
+
+	return res, nil
+}
+
+func (s *PRService) ListEvents(ctx context.Context, prID uuid.UUID) ([]entity.PREvent, error) {
+	ctx, span := tracer.Start(ctx, "PRService.ListEvents")
+	defer span.End()
+
+	if _, err := s.prs.GetByID(ctx, prID); err != nil {
+		return nil, err
+	}
+
+	return s.prs.ListEvents(ctx, prID)
+}
 
-		oldReviewer, err := s.users.GetByID(txCtx, oldReviewerID)
+// RecordReview marks that the reviewer has reviewed the PR; reviewed assignments are no longer
+// overdue nor escalated, and feed the time-to-first-review metrics.
+func (s *PRService) RecordReview(ctx context.Context, prID, reviewerID uuid.UUID) (entity.PREvent, error) {
+	ctx, span := tracer.Start(ctx, "PRService.RecordReview")
+	defer span.End()
+
+	var ev entity.PREvent
+
+	err := s.tx.InTx(ctx, app.TxOptions{}, func(txCtx context.Context) error {
+		pr, err := s.prs.GetByIDForUpdate(txCtx, prID)
 		if err != nil {
 			return err
 		}
 
-		activeUsers, err := s.users.ListActiveByTeamName(txCtx, oldReviewer.TeamName)
-		if err != nil {
+		if err := s.authorizePRActor(txCtx, pr, reviewerID); err != nil {
 			return err
 		}
 
-		candidates := make([]uuid.UUID, 0, len(activeUsers))
-		for _, u := range activeUsers {
-			if u.ID == oldReviewer.ID || u.ID == pr.AuthorID {
-				continue
-			}
-
-			isAlreadyReviewer := false
-			for _, r := range pr.Reviewers {
-				if r == u.ID {
-					isAlreadyReviewer = true
-					break
-				}
-			}
-			if isAlreadyReviewer {
-				continue
-			}
-
-			candidates = append(candidates, u.ID)
+		if pr.IsMerged() {
+			return common.ErrPRMerged
 		}
-
-		if len(candidates) == 0 {
-			return common.ErrNoCandidate
+		if !pr.HasReviewer(reviewerID) {
+			return common.ErrNotAssigned
 		}
 
-		newReviewerID := candidates[0]
-		pr.Reviewers[idx] = newReviewerID
-
-		if err := s.prs.Update(txCtx, pr); err != nil {
-			return err
+		ev = entity.PREvent{
+			ID:         uuid.New(),
+			PRID:       pr.ID,
+			Kind:       entity.EventReviewed,
+			ReviewerID: reviewerID,
+			CreatedAt:  s.clock.Now(),
 		}
 
-		result = pr
-		return nil
+		return s.prs.CreateEvent(txCtx, ev)
 	})
 	if err != nil {
-		return entity.PR{}, err
+		return entity.PREvent{}, err
 	}
 
-	return result, nil
+	return ev, nil
 }
@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -30,10 +33,10 @@ func TestPRService_Create_AssignsReviewers(t *testing.T) {
 	userRepo.users[r2] = entity.User{ID: r2, TeamName: teamName, Name: "R2", IsActive: true}
 	userRepo.users[r3] = entity.User{ID: r3, TeamName: teamName, Name: "R3", IsActive: true}
 
-	svc := NewPRService(prRepo, userRepo, tx, clock)
+	svc := NewPRService(prRepo, userRepo, newFakeTeamRepo(), tx, clock, &fakeMetrics{}, discardLogger)
 
 	prID := uuid.New()
-	pr, err := svc.Create(ctx, prID, "Add search", authorID)
+	pr, _, err := svc.Create(ctx, entity.PR{ID: prID, Title: "Add search", AuthorID: authorID})
 	if err != nil {
 		t.Fatalf("Create returned error: %v", err)
 	}
@@ -75,10 +78,10 @@ func TestPRService_Create_NoOtherActiveUsers(t *testing.T) {
 		IsActive: true,
 	}
 
-	svc := NewPRService(prRepo, userRepo, tx, clock)
+	svc := NewPRService(prRepo, userRepo, newFakeTeamRepo(), tx, clock, &fakeMetrics{}, discardLogger)
 
 	prID := uuid.New()
-	pr, err := svc.Create(ctx, prID, "Lonely PR", authorID)
+	pr, _, err := svc.Create(ctx, entity.PR{ID: prID, Title: "Lonely PR", AuthorID: authorID})
 	if err != nil {
 		t.Fatalf("Create returned error: %v", err)
 	}
@@ -96,7 +99,7 @@ func TestPRService_Merge_Idempotent(t *testing.T) {
 	tx := fakeTx{}
 	clock := common.StandardClock{}
 
-	svc := NewPRService(prRepo, userRepo, tx, clock)
+	svc := NewPRService(prRepo, userRepo, newFakeTeamRepo(), tx, clock, &fakeMetrics{}, discardLogger)
 
 	prID := uuid.New()
 	prRepo.prs[prID] = entity.PR{
@@ -106,7 +109,7 @@ func TestPRService_Merge_Idempotent(t *testing.T) {
 		Status:   entity.StatusOpen,
 	}
 
-	first, err := svc.Merge(ctx, prID)
+	first, err := svc.Merge(ctx, prID, 0)
 	if err != nil {
 		t.Fatalf("first Merge error: %v", err)
 	}
@@ -117,7 +120,7 @@ func TestPRService_Merge_Idempotent(t *testing.T) {
 		t.Fatalf("first Merge: mergedAt is nil")
 	}
 
-	second, err := svc.Merge(ctx, prID)
+	second, err := svc.Merge(ctx, prID, 0)
 	if err != nil {
 		t.Fatalf("second Merge error: %v", err)
 	}
@@ -132,6 +135,59 @@ func TestPRService_Merge_Idempotent(t *testing.T) {
 	}
 }
 
+func TestPRService_Merge_Version(t *testing.T) {
+	ctx := context.Background()
+
+	prRepo := newFakePRRepo()
+	svc := NewPRService(prRepo, newFakeUserRepo(), newFakeTeamRepo(), fakeTx{}, common.StandardClock{}, &fakeMetrics{}, discardLogger)
+
+	prID := uuid.New()
+	prRepo.prs[prID] = entity.PR{
+		ID:       prID,
+		Title:    "Some PR",
+		AuthorID: uuid.New(),
+		Status:   entity.StatusOpen,
+		Version:  3,
+	}
+
+	if _, err := svc.Merge(ctx, prID, 2); !errors.Is(err, common.ErrConflict) {
+		t.Fatalf("stale version: expected ErrConflict, got %v", err)
+	}
+	if prRepo.prs[prID].IsMerged() {
+		t.Fatalf("stale version: PR must not be merged")
+	}
+
+	pr, err := svc.Merge(ctx, prID, 3)
+	if err != nil {
+		t.Fatalf("Merge error: %v", err)
+	}
+	if pr.Version != 4 || prRepo.prs[prID].Version != 4 {
+		t.Fatalf("expected version 4, got %d (stored %d)", pr.Version, prRepo.prs[prID].Version)
+	}
+}
+
+func TestPRService_Reassign_StaleVersion(t *testing.T) {
+	prRepo := newFakePRRepo()
+	svc := NewPRService(prRepo, newFakeUserRepo(), newFakeTeamRepo(), fakeTx{}, common.StandardClock{}, &fakeMetrics{}, discardLogger)
+
+	prID, oldID := uuid.New(), uuid.New()
+	prRepo.prs[prID] = entity.PR{
+		ID:        prID,
+		Title:     "PR",
+		AuthorID:  uuid.New(),
+		Status:    entity.StatusOpen,
+		Reviewers: []uuid.UUID{oldID},
+		Version:   2,
+	}
+
+	if _, _, err := svc.ReassignReviewer(context.Background(), prID, oldID, 1); !errors.Is(err, common.ErrConflict) {
+		t.Fatalf("expected ErrConflict, got %v", err)
+	}
+	if !prRepo.prs[prID].HasReviewer(oldID) {
+		t.Fatalf("reviewer must not be replaced on a stale version")
+	}
+}
+
 func TestPRService_Reassign_MergedPR(t *testing.T) {
 	ctx := context.Background()
 
@@ -151,9 +207,9 @@ func TestPRService_Reassign_MergedPR(t *testing.T) {
 		Reviewers: []uuid.UUID{oldID},
 	}
 
-	svc := NewPRService(prRepo, userRepo, tx, clock)
+	svc := NewPRService(prRepo, userRepo, newFakeTeamRepo(), tx, clock, &fakeMetrics{}, discardLogger)
 
-	_, err := svc.ReassignReviewer(ctx, prID, oldID)
+	_, _, err := svc.ReassignReviewer(ctx, prID, oldID, 0)
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -187,9 +243,9 @@ func TestPRService_Reassign_OldReviewerNotAssigned(t *testing.T) {
 		Reviewers: []uuid.UUID{otherReviewer},
 	}
 
-	svc := NewPRService(prRepo, userRepo, tx, clock)
+	svc := NewPRService(prRepo, userRepo, newFakeTeamRepo(), tx, clock, &fakeMetrics{}, discardLogger)
 
-	_, err := svc.ReassignReviewer(ctx, prID, oldID)
+	_, _, err := svc.ReassignReviewer(ctx, prID, oldID, 0)
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -221,9 +277,9 @@ func TestPRService_Reassign_NoCandidate(t *testing.T) {
 		Reviewers: []uuid.UUID{oldID},
 	}
 
-	svc := NewPRService(prRepo, userRepo, tx, clock)
+	svc := NewPRService(prRepo, userRepo, newFakeTeamRepo(), tx, clock, &fakeMetrics{}, discardLogger)
 
-	_, err := svc.ReassignReviewer(ctx, prID, oldID)
+	_, _, err := svc.ReassignReviewer(ctx, prID, oldID, 0)
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -259,9 +315,9 @@ func TestPRService_Reassign_Success(t *testing.T) {
 		Reviewers: []uuid.UUID{oldID, otherReviewer},
 	}
 
-	svc := NewPRService(prRepo, userRepo, tx, clock)
+	svc := NewPRService(prRepo, userRepo, newFakeTeamRepo(), tx, clock, &fakeMetrics{}, discardLogger)
 
-	res, err := svc.ReassignReviewer(ctx, prID, oldID)
+	res, _, err := svc.ReassignReviewer(ctx, prID, oldID, 0)
 	if err != nil {
 		t.Fatalf("ReassignReviewer error: %v", err)
 	}
@@ -288,3 +344,642 @@ func TestPRService_Reassign_Success(t *testing.T) {
 		t.Fatalf("new candidate must be in reviewers")
 	}
 }
+
+func TestPRService_Create_PrefersCodeOwners(t *testing.T) {
+	authorID := uuid.New()
+	r1 := uuid.New()
+	r2 := uuid.New()
+	owner := uuid.New()
+
+	tests := []struct {
+		name      string
+		pattern   string
+		file      string
+		wantOwner bool
+	}{
+		{name: "extension anywhere", pattern: "*.go", file: "internal/app/service/pr.go", wantOwner: true},
+		{name: "extension mismatch", pattern: "*.go", file: "migrations/001.sql", wantOwner: false},
+		{name: "anchored directory", pattern: "/migrations/", file: "migrations/001.sql", wantOwner: true},
+		{name: "anchored directory nested", pattern: "/migrations/", file: "db/migrations/001.sql", wantOwner: false},
+		{name: "unanchored directory", pattern: "service/", file: "internal/app/service/pr.go", wantOwner: true},
+		{name: "direct children only", pattern: "docs/*", file: "docs/build/index.md", wantOwner: false},
+		{name: "direct child", pattern: "docs/*", file: "docs/index.md", wantOwner: true},
+		{name: "double star", pattern: "**/logs", file: "build/deep/logs/a.txt", wantOwner: true},
+		{name: "path without trailing slash owns subtree", pattern: "/internal/app", file: "internal/app/ports.go", wantOwner: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			userRepo := newFakeUserRepo()
+			prRepo := newFakePRRepo()
+			teamRepo := newFakeTeamRepo()
+
+			userRepo.users[authorID] = entity.User{ID: authorID, TeamName: teamName, Name: "Author", IsActive: true}
+			userRepo.users[r1] = entity.User{ID: r1, TeamName: teamName, Name: "R1", IsActive: true}
+			userRepo.users[r2] = entity.User{ID: r2, TeamName: teamName, Name: "R2", IsActive: true}
+			userRepo.users[owner] = entity.User{ID: owner, TeamName: teamName, Name: "Owner", IsActive: true}
+
+			teamRepo.codeOwners[teamName] = entity.CodeOwners{
+				TeamName: teamName,
+				Rules: []entity.CodeOwnerRule{
+					{Pattern: tt.pattern, Owners: []uuid.UUID{owner}},
+				},
+			}
+
+			svc := NewPRService(prRepo, userRepo, teamRepo, fakeTx{}, common.StandardClock{}, &fakeMetrics{}, discardLogger)
+
+			pr, _, err := svc.Create(ctx, entity.PR{
+				ID:           uuid.New(),
+				Title:        "PR",
+				AuthorID:     authorID,
+				ChangedFiles: []string{tt.file},
+			})
+			if err != nil {
+				t.Fatalf("Create returned error: %v", err)
+			}
+
+			if tt.wantOwner && (len(pr.Reviewers) == 0 || pr.Reviewers[0] != owner) {
+				t.Fatalf("expected code owner %s to be the first reviewer, got %v", owner, pr.Reviewers)
+			}
+			if !tt.wantOwner && len(pr.Reviewers) != 2 {
+				t.Fatalf("expected 2 reviewers from team pool, got %d", len(pr.Reviewers))
+			}
+		})
+	}
+}
+
+func TestPRService_Create_LastMatchingRuleWins(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newFakeUserRepo()
+	prRepo := newFakePRRepo()
+	teamRepo := newFakeTeamRepo()
+
+	authorID := uuid.New()
+	generalOwner := uuid.New()
+	sqlOwner := uuid.New()
+	other := uuid.New()
+
+	userRepo.users[authorID] = entity.User{ID: authorID, TeamName: teamName, Name: "Author", IsActive: true}
+	userRepo.users[generalOwner] = entity.User{ID: generalOwner, TeamName: teamName, Name: "General", IsActive: true}
+	userRepo.users[sqlOwner] = entity.User{ID: sqlOwner, TeamName: teamName, Name: "SQL", IsActive: true}
+	userRepo.users[other] = entity.User{ID: other, TeamName: teamName, Name: "Other", IsActive: true}
+
+	teamRepo.codeOwners[teamName] = entity.CodeOwners{
+		TeamName: teamName,
+		Rules: []entity.CodeOwnerRule{
+			{Pattern: "*", Owners: []uuid.UUID{generalOwner}},
+			{Pattern: "*.sql", Owners: []uuid.UUID{sqlOwner, authorID}},
+		},
+	}
+
+	svc := NewPRService(prRepo, userRepo, teamRepo, fakeTx{}, common.StandardClock{}, &fakeMetrics{}, discardLogger)
+
+	pr, _, err := svc.Create(ctx, entity.PR{
+		ID:           uuid.New(),
+		Title:        "Migration",
+		AuthorID:     authorID,
+		ChangedFiles: []string{"migrations/003.sql"},
+	})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	if len(pr.Reviewers) != 2 {
+		t.Fatalf("expected 2 reviewers, got %d", len(pr.Reviewers))
+	}
+	if pr.Reviewers[0] != sqlOwner {
+		t.Fatalf("expected sql owner first, got %v", pr.Reviewers)
+	}
+	for _, rid := range pr.Reviewers {
+		if rid == authorID {
+			t.Fatalf("author must not be a reviewer even if owns the path")
+		}
+	}
+}
+
+func TestPRService_Create_CoversRequiredTags(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newFakeUserRepo()
+	prRepo := newFakePRRepo()
+
+	authorID := uuid.New()
+	goDev := uuid.New()
+	goSQLDev := uuid.New()
+	frontendDev := uuid.New()
+	noSkills := uuid.New()
+
+	userRepo.users[authorID] = entity.User{ID: authorID, TeamName: teamName, Name: "Author", IsActive: true, Skills: []string{"go", "sql", "frontend"}}
+	userRepo.users[goDev] = entity.User{ID: goDev, TeamName: teamName, Name: "Go", IsActive: true, Skills: []string{"go"}}
+	userRepo.users[goSQLDev] = entity.User{ID: goSQLDev, TeamName: teamName, Name: "GoSQL", IsActive: true, Skills: []string{"go", "sql"}}
+	userRepo.users[frontendDev] = entity.User{ID: frontendDev, TeamName: teamName, Name: "Front", IsActive: true, Skills: []string{"frontend"}}
+	userRepo.users[noSkills] = entity.User{ID: noSkills, TeamName: teamName, Name: "Plain", IsActive: true}
+
+	svc := NewPRService(prRepo, userRepo, newFakeTeamRepo(), fakeTx{}, common.StandardClock{}, &fakeMetrics{}, discardLogger)
+
+	pr, choices, err := svc.Create(ctx, entity.PR{
+		ID:           uuid.New(),
+		Title:        "Full stack",
+		AuthorID:     authorID,
+		RequiredTags: []string{" Go", "SQL", "frontend", "go"},
+	})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	if len(pr.RequiredTags) != 3 {
+		t.Fatalf("expected normalized tags [go sql frontend], got %v", pr.RequiredTags)
+	}
+
+	if len(pr.Reviewers) != 2 || pr.Reviewers[0] != goSQLDev || pr.Reviewers[1] != frontendDev {
+		t.Fatalf("expected reviewers [%s %s], got %v", goSQLDev, frontendDev, pr.Reviewers)
+	}
+
+	if len(choices) != 2 {
+		t.Fatalf("expected 2 choices, got %d", len(choices))
+	}
+	if got := choices[0].Reasons; len(got) != 1 || got[0] != "covers required tags: go, sql" {
+		t.Fatalf("unexpected reasons for first reviewer: %v", got)
+	}
+	if got := choices[1].Reasons; len(got) != 1 || got[0] != "covers required tags: frontend" {
+		t.Fatalf("unexpected reasons for second reviewer: %v", got)
+	}
+}
+
+func TestPRService_Reassign_KeepsTagCoverage(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newFakeUserRepo()
+	prRepo := newFakePRRepo()
+
+	authorID := uuid.New()
+	oldID := uuid.New()
+	goReviewer := uuid.New()
+	plain := uuid.New()
+	security := uuid.New()
+
+	userRepo.users[authorID] = entity.User{ID: authorID, TeamName: teamName, Name: "Author", IsActive: true}
+	userRepo.users[oldID] = entity.User{ID: oldID, TeamName: teamName, Name: "Old", IsActive: true, Skills: []string{"security"}}
+	userRepo.users[goReviewer] = entity.User{ID: goReviewer, TeamName: teamName, Name: "Go", IsActive: true, Skills: []string{"go"}}
+	userRepo.users[plain] = entity.User{ID: plain, TeamName: teamName, Name: "Plain", IsActive: true, Skills: []string{"go"}}
+	userRepo.users[security] = entity.User{ID: security, TeamName: teamName, Name: "Sec", IsActive: true, Skills: []string{"security"}}
+
+	prID := uuid.New()
+	prRepo.prs[prID] = entity.PR{
+		ID:           prID,
+		Title:        "PR",
+		AuthorID:     authorID,
+		Status:       entity.StatusOpen,
+		RequiredTags: []string{"go", "security"},
+		Reviewers:    []uuid.UUID{oldID, goReviewer},
+	}
+
+	svc := NewPRService(prRepo, userRepo, newFakeTeamRepo(), fakeTx{}, common.StandardClock{}, &fakeMetrics{}, discardLogger)
+
+	res, choice, err := svc.ReassignReviewer(ctx, prID, oldID, 0)
+	if err != nil {
+		t.Fatalf("ReassignReviewer error: %v", err)
+	}
+
+	if choice.UserID != security {
+		t.Fatalf("expected security reviewer %s, got %s", security, choice.UserID)
+	}
+	if res.Reviewers[0] != security || res.Reviewers[1] != goReviewer {
+		t.Fatalf("unexpected reviewers %v", res.Reviewers)
+	}
+}
+
+func TestPRService_Create_PrefersReviewersInWorkingHours(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newFakeUserRepo()
+	prRepo := newFakePRRepo()
+	teamRepo := newFakeTeamRepo()
+	teamRepo.settings[teamName] = entity.TeamSettings{TeamName: teamName, PreferWorkingHours: true}
+
+	// Monday, 06:30 UTC: 08:30 in Berlin.
+	clock := fakeClock{now: time.Date(2026, 10, 19, 6, 30, 0, 0, time.UTC)}
+
+	authorID := uuid.New()
+	berlin := uuid.New()
+	nightShift := uuid.New()
+	noProfile := uuid.New()
+
+	userRepo.users[authorID] = entity.User{ID: authorID, TeamName: teamName, Name: "Author", IsActive: true}
+	userRepo.users[berlin] = entity.User{ID: berlin, TeamName: teamName, Name: "Berlin", IsActive: true,
+		WorkingHours: &entity.WorkingHours{Timezone: "Europe/Berlin", StartMinute: 9 * 60, EndMinute: 18 * 60, Days: entity.DefaultWorkDays()}}
+	userRepo.users[nightShift] = entity.User{ID: nightShift, TeamName: teamName, Name: "Night", IsActive: true,
+		WorkingHours: &entity.WorkingHours{Timezone: "UTC", StartMinute: 22 * 60, EndMinute: 8 * 60, Days: []time.Weekday{time.Sunday}}}
+	userRepo.users[noProfile] = entity.User{ID: noProfile, TeamName: teamName, Name: "Plain", IsActive: true}
+
+	svc := NewPRService(prRepo, userRepo, teamRepo, fakeTx{}, clock, &fakeMetrics{}, discardLogger)
+
+	pr, choices, err := svc.Create(ctx, entity.PR{ID: uuid.New(), Title: "Hotfix", AuthorID: authorID})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	if len(pr.Reviewers) != 2 {
+		t.Fatalf("expected 2 reviewers, got %v", pr.Reviewers)
+	}
+	for _, id := range pr.Reviewers {
+		if id == berlin {
+			t.Fatalf("reviewer outside working hours must not be picked while others are available")
+		}
+	}
+
+	for _, c := range choices {
+		if c.UserID == nightShift && (len(c.Reasons) != 1 || c.Reasons[0] != "inside working hours (22:00-08:00 UTC)") {
+			t.Fatalf("unexpected reasons for night shift reviewer: %v", c.Reasons)
+		}
+	}
+}
+
+func TestPRService_Create_SkipsReviewersAtCapacity(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newFakeUserRepo()
+	prRepo := newFakePRRepo()
+
+	one, two := 1, 2
+
+	authorID := uuid.New()
+	busy := uuid.New()
+	free := uuid.New()
+
+	userRepo.users[authorID] = entity.User{ID: authorID, TeamName: teamName, Name: "Author", IsActive: true}
+	userRepo.users[busy] = entity.User{ID: busy, TeamName: teamName, Name: "Busy", IsActive: true, MaxOpenReviews: &one}
+	userRepo.users[free] = entity.User{ID: free, TeamName: teamName, Name: "Free", IsActive: true, MaxOpenReviews: &two}
+
+	existing := uuid.New()
+	prRepo.prs[existing] = entity.PR{ID: existing, AuthorID: authorID, Status: entity.StatusOpen, Reviewers: []uuid.UUID{busy, free}}
+
+	svc := NewPRService(prRepo, userRepo, newFakeTeamRepo(), fakeTx{}, common.StandardClock{}, &fakeMetrics{}, discardLogger)
+
+	pr, _, err := svc.Create(ctx, entity.PR{ID: uuid.New(), Title: "Feature", AuthorID: authorID})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	if len(pr.Reviewers) != 1 || pr.Reviewers[0] != free {
+		t.Fatalf("expected only %s to be assigned, got %v", free, pr.Reviewers)
+	}
+}
+
+func TestPRService_Create_EveryoneAtCapacity(t *testing.T) {
+	one := 1
+
+	newRepos := func() (*fakeUserRepo, *fakePRRepo, uuid.UUID, uuid.UUID, uuid.UUID) {
+		userRepo := newFakeUserRepo()
+		prRepo := newFakePRRepo()
+
+		authorID := uuid.New()
+		light := uuid.New()
+		heavy := uuid.New()
+
+		userRepo.users[authorID] = entity.User{ID: authorID, TeamName: teamName, Name: "Author", IsActive: true}
+		userRepo.users[light] = entity.User{ID: light, TeamName: teamName, Name: "Light", IsActive: true, MaxOpenReviews: &one}
+		userRepo.users[heavy] = entity.User{ID: heavy, TeamName: teamName, Name: "Heavy", IsActive: true, MaxOpenReviews: &one}
+
+		pr1, pr2 := uuid.New(), uuid.New()
+		prRepo.prs[pr1] = entity.PR{ID: pr1, AuthorID: authorID, Status: entity.StatusOpen, Reviewers: []uuid.UUID{light, heavy}}
+		prRepo.prs[pr2] = entity.PR{ID: pr2, AuthorID: authorID, Status: entity.StatusOpen, Reviewers: []uuid.UUID{heavy}}
+
+		return userRepo, prRepo, authorID, light, heavy
+	}
+
+	t.Run("strict policy fails", func(t *testing.T) {
+		userRepo, prRepo, authorID, _, _ := newRepos()
+
+		svc := NewPRService(prRepo, userRepo, newFakeTeamRepo(), fakeTx{}, common.StandardClock{}, &fakeMetrics{}, discardLogger)
+
+		_, _, err := svc.Create(context.Background(), entity.PR{ID: uuid.New(), Title: "Feature", AuthorID: authorID})
+		if !errors.Is(err, common.ErrNoCandidate) {
+			t.Fatalf("expected ErrNoCandidate, got %v", err)
+		}
+	})
+
+	t.Run("over-assign picks least loaded first", func(t *testing.T) {
+		userRepo, prRepo, authorID, light, heavy := newRepos()
+
+		teamRepo := newFakeTeamRepo()
+		teamRepo.settings[teamName] = entity.TeamSettings{TeamName: teamName, CapacityPolicy: entity.CapacityOverAssign}
+
+		svc := NewPRService(prRepo, userRepo, teamRepo, fakeTx{}, common.StandardClock{}, &fakeMetrics{}, discardLogger)
+
+		pr, choices, err := svc.Create(context.Background(), entity.PR{ID: uuid.New(), Title: "Feature", AuthorID: authorID})
+		if err != nil {
+			t.Fatalf("Create returned error: %v", err)
+		}
+
+		if len(pr.Reviewers) != 2 || pr.Reviewers[0] != light || pr.Reviewers[1] != heavy {
+			t.Fatalf("expected reviewers [%s %s], got %v", light, heavy, pr.Reviewers)
+		}
+		if got := choices[0].Reasons; len(got) != 1 || got[0] != "everyone is at capacity, least loaded (1 open reviews, limit 1)" {
+			t.Fatalf("unexpected reasons: %v", got)
+		}
+	})
+}
+
+func TestPRService_Create_EnforcesTeamRules(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newFakeUserRepo()
+	prRepo := newFakePRRepo()
+	teamRepo := newFakeTeamRepo()
+
+	authorID := uuid.New()
+	mentor := uuid.New()
+	pairA := uuid.New()
+	pairB := uuid.New()
+	plain := uuid.New()
+
+	userRepo.users[authorID] = entity.User{ID: authorID, TeamName: teamName, Name: "Author", IsActive: true}
+	userRepo.users[mentor] = entity.User{ID: mentor, TeamName: teamName, Name: "Mentor", IsActive: true, IsSenior: true}
+	userRepo.users[pairA] = entity.User{ID: pairA, TeamName: teamName, Name: "A", IsActive: true, IsSenior: true}
+	userRepo.users[pairB] = entity.User{ID: pairB, TeamName: teamName, Name: "B", IsActive: true}
+	userRepo.users[plain] = entity.User{ID: plain, TeamName: teamName, Name: "Plain", IsActive: true}
+
+	teamRepo.rules[teamName] = entity.TeamRules{TeamName: teamName, Rules: []entity.TeamRule{
+		{Kind: entity.RuleNever, AuthorID: authorID, Reviewers: []uuid.UUID{mentor}},
+		{Kind: entity.RuleRequireOneOf, AuthorID: authorID, Reviewers: []uuid.UUID{pairA, pairB}},
+		{Kind: entity.RuleRequireSenior},
+	}}
+
+	svc := NewPRService(prRepo, userRepo, teamRepo, fakeTx{}, common.StandardClock{}, &fakeMetrics{}, discardLogger)
+
+	pr, choices, err := svc.Create(ctx, entity.PR{ID: uuid.New(), Title: "Feature", AuthorID: authorID})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	if len(pr.Reviewers) != 2 || pr.Reviewers[0] != pairA {
+		t.Fatalf("expected %s to be picked first, got %v", pairA, pr.Reviewers)
+	}
+	if pr.Reviewers[1] == mentor {
+		t.Fatalf("mentor must never review the author's PRs")
+	}
+	if got := choices[0].Reasons; len(got) != 2 {
+		t.Fatalf("expected both requirement rules in reasons, got %v", got)
+	}
+}
+
+func TestPRService_Create_RulesUnsatisfied(t *testing.T) {
+	userRepo := newFakeUserRepo()
+	teamRepo := newFakeTeamRepo()
+
+	authorID := uuid.New()
+	junior := uuid.New()
+
+	userRepo.users[authorID] = entity.User{ID: authorID, TeamName: teamName, Name: "Author", IsActive: true}
+	userRepo.users[junior] = entity.User{ID: junior, TeamName: teamName, Name: "Junior", IsActive: true}
+
+	teamRepo.rules[teamName] = entity.TeamRules{TeamName: teamName, Rules: []entity.TeamRule{{Kind: entity.RuleRequireSenior}}}
+
+	svc := NewPRService(newFakePRRepo(), userRepo, teamRepo, fakeTx{}, common.StandardClock{}, &fakeMetrics{}, discardLogger)
+
+	_, _, err := svc.Create(context.Background(), entity.PR{ID: uuid.New(), Title: "Feature", AuthorID: authorID})
+	if !errors.Is(err, common.ErrRulesUnsatisfied) {
+		t.Fatalf("expected ErrRulesUnsatisfied, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "at least one senior") {
+		t.Fatalf("error must describe the broken rule, got %q", err)
+	}
+}
+
+func TestPRService_Reassign_KeepsSeniorRule(t *testing.T) {
+	userRepo := newFakeUserRepo()
+	prRepo := newFakePRRepo()
+	teamRepo := newFakeTeamRepo()
+
+	authorID := uuid.New()
+	senior := uuid.New()
+	other := uuid.New()
+	juniors := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	replacement := uuid.New()
+
+	userRepo.users[authorID] = entity.User{ID: authorID, TeamName: teamName, Name: "Author", IsActive: true}
+	userRepo.users[senior] = entity.User{ID: senior, TeamName: teamName, Name: "Senior", IsActive: true, IsSenior: true}
+	userRepo.users[other] = entity.User{ID: other, TeamName: teamName, Name: "Other", IsActive: true}
+	userRepo.users[replacement] = entity.User{ID: replacement, TeamName: teamName, Name: "Replacement", IsActive: true, IsSenior: true}
+	for _, id := range juniors {
+		userRepo.users[id] = entity.User{ID: id, TeamName: teamName, Name: "Junior", IsActive: true}
+	}
+
+	teamRepo.rules[teamName] = entity.TeamRules{TeamName: teamName, Rules: []entity.TeamRule{{Kind: entity.RuleRequireSenior}}}
+
+	prID := uuid.New()
+	prRepo.prs[prID] = entity.PR{ID: prID, AuthorID: authorID, Status: entity.StatusOpen, Reviewers: []uuid.UUID{senior, other}}
+
+	svc := NewPRService(prRepo, userRepo, teamRepo, fakeTx{}, common.StandardClock{}, &fakeMetrics{}, discardLogger)
+
+	_, choice, err := svc.ReassignReviewer(context.Background(), prID, senior, 0)
+	if err != nil {
+		t.Fatalf("ReassignReviewer error: %v", err)
+	}
+	if choice.UserID != replacement {
+		t.Fatalf("expected senior %s as replacement, got %s", replacement, choice.UserID)
+	}
+
+	if d := prRepo.decisions; len(d) != 1 || d[0].Kind != entity.AssignmentReassign || d[0].ReplacedUserID != senior {
+		t.Fatalf("reassignment must be recorded with the replaced reviewer, got %+v", d)
+	}
+}
+
+func TestPRService_RecordsAssignmentDecisions(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newFakeUserRepo()
+	prRepo := newFakePRRepo()
+	teamRepo := newFakeTeamRepo()
+	clock := fakeClock{now: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)}
+
+	authorID := uuid.New()
+	anna := uuid.New()
+	boris := uuid.New()
+	clara := uuid.New()
+	dmitry := uuid.New()
+	elena := uuid.New()
+
+	userRepo.users[authorID] = entity.User{ID: authorID, TeamName: teamName, Name: "Author", IsActive: true}
+	userRepo.users[anna] = entity.User{ID: anna, TeamName: teamName, Name: "Anna", IsActive: true, Skills: []string{"sql"}}
+	userRepo.users[boris] = entity.User{ID: boris, TeamName: teamName, Name: "Boris", IsActive: true}
+	userRepo.users[clara] = entity.User{ID: clara, TeamName: teamName, Name: "Clara", IsActive: false}
+	userRepo.users[dmitry] = entity.User{ID: dmitry, TeamName: teamName, Name: "Dmitry", IsActive: true}
+	userRepo.users[elena] = entity.User{ID: elena, TeamName: teamName, Name: "Elena", IsActive: true}
+
+	leaveID := uuid.New()
+	userRepo.leaves[leaveID] = entity.Unavailability{ID: leaveID, UserID: dmitry, StartsAt: clock.now.Add(-time.Hour), EndsAt: clock.now.Add(time.Hour)}
+
+	teamRepo.rules[teamName] = entity.TeamRules{TeamName: teamName, Rules: []entity.TeamRule{
+		{Kind: entity.RuleNever, AuthorID: authorID, Reviewers: []uuid.UUID{elena}},
+	}}
+
+	svc := NewPRService(prRepo, userRepo, teamRepo, fakeTx{}, clock, &fakeMetrics{}, discardLogger)
+
+	pr, _, err := svc.Create(ctx, entity.PR{ID: uuid.New(), Title: "Schema", AuthorID: authorID, RequiredTags: []string{"sql"}})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	if _, _, err := svc.ReassignReviewer(ctx, pr.ID, boris, 0); !errors.Is(err, common.ErrNoCandidate) {
+		t.Fatalf("expected ErrNoCandidate, got %v", err)
+	}
+
+	decisions, err := svc.ExplainAssignment(ctx, pr.ID)
+	if err != nil {
+		t.Fatalf("ExplainAssignment returned error: %v", err)
+	}
+	if len(decisions) != 1 {
+		t.Fatalf("expected only the successful assignment to be recorded, got %d", len(decisions))
+	}
+
+	d := decisions[0]
+	if d.Kind != entity.AssignmentCreate || d.Strategy != "required_tags > pool_order" {
+		t.Fatalf("unexpected kind/strategy: %s %q", d.Kind, d.Strategy)
+	}
+	if len(d.Pool) != 2 || d.Pool[0] != anna || d.Pool[1] != boris {
+		t.Fatalf("unexpected pool: %v", d.Pool)
+	}
+
+	wantExcluded := map[uuid.UUID]string{
+		authorID: "author of the PR",
+		clara:    "inactive",
+		dmitry:   "out of office",
+		elena:    "team rule: never assign " + elena.String() + " to PRs of " + authorID.String(),
+	}
+	if len(d.Excluded) != len(wantExcluded) {
+		t.Fatalf("unexpected excluded: %v", d.Excluded)
+	}
+	for _, x := range d.Excluded {
+		if wantExcluded[x.UserID] != x.Reason {
+			t.Fatalf("user %s excluded with %q, want %q", x.UserID, x.Reason, wantExcluded[x.UserID])
+		}
+	}
+
+	if len(d.Chosen) != 2 || d.Chosen[0].UserID != anna || d.Chosen[0].Score.TagsCovered != 1 || d.Chosen[1].Score.PoolOrder <= d.Chosen[0].Score.PoolOrder {
+		t.Fatalf("unexpected chosen: %+v", d.Chosen)
+	}
+
+	if _, err := svc.ExplainAssignment(ctx, uuid.New()); !errors.Is(err, common.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for unknown PR, got %v", err)
+	}
+}
+
+func TestPRService_ListOverdue(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newFakeUserRepo()
+	prRepo := newFakePRRepo()
+	teamRepo := newFakeTeamRepo()
+	teamRepo.teams[teamName] = entity.Team{Name: teamName}
+
+	start := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	clock := &fakeClock{now: start}
+
+	authorID := uuid.New()
+	r1 := uuid.New()
+	r2 := uuid.New()
+	r3 := uuid.New()
+
+	userRepo.users[authorID] = entity.User{ID: authorID, TeamName: teamName, Name: "Author", IsActive: true}
+	userRepo.users[r1] = entity.User{ID: r1, TeamName: teamName, Name: "R1", IsActive: true}
+	userRepo.users[r2] = entity.User{ID: r2, TeamName: teamName, Name: "R2", IsActive: true}
+	userRepo.users[r3] = entity.User{ID: r3, TeamName: teamName, Name: "R3", IsActive: true}
+
+	svc := NewPRService(prRepo, userRepo, teamRepo, fakeTx{}, clock, &fakeMetrics{}, discardLogger)
+
+	pr, _, err := svc.Create(ctx, entity.PR{ID: uuid.New(), Title: "Slow", AuthorID: authorID})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	overdue, err := svc.ListOverdue(ctx, teamName)
+	if err != nil || len(overdue) != 0 {
+		t.Fatalf("without SLA nothing is overdue, got %v, %v", overdue, err)
+	}
+
+	teamRepo.settings[teamName] = entity.TeamSettings{TeamName: teamName, CapacityPolicy: entity.CapacityStrict, ReviewSLA: 24 * time.Hour}
+
+	clock.now = start.Add(20 * time.Hour)
+	pr, replacement, err := svc.ReassignReviewer(ctx, pr.ID, pr.Reviewers[0], 0)
+	if err != nil {
+		t.Fatalf("ReassignReviewer error: %v", err)
+	}
+
+	clock.now = start.Add(30 * time.Hour)
+	overdue, err = svc.ListOverdue(ctx, teamName)
+	if err != nil {
+		t.Fatalf("ListOverdue returned error: %v", err)
+	}
+
+	if len(overdue) != 1 {
+		t.Fatalf("expected 1 overdue review, got %+v", overdue)
+	}
+
+	o := overdue[0]
+	if o.Assignment.ReviewerID == replacement.UserID {
+		t.Fatalf("reassigned reviewer must get a fresh assignment time")
+	}
+	if o.Age != 30*time.Hour || o.OverdueBy() != 6*time.Hour {
+		t.Fatalf("unexpected age %v / overdue by %v", o.Age, o.OverdueBy())
+	}
+
+	if _, err := svc.ListOverdue(ctx, "unknown"); !errors.Is(err, common.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestPRService_RecordsMetrics(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newFakeUserRepo()
+	prRepo := newFakePRRepo()
+	metrics := &fakeMetrics{}
+
+	authorID, r1, r2, r3 := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	userRepo.users[authorID] = entity.User{ID: authorID, TeamName: teamName, Name: "Author", IsActive: true}
+	userRepo.users[r1] = entity.User{ID: r1, TeamName: teamName, Name: "R1", IsActive: true}
+	userRepo.users[r2] = entity.User{ID: r2, TeamName: teamName, Name: "R2", IsActive: true}
+	prRepo.authorTeams = map[uuid.UUID]string{authorID: teamName}
+
+	svc := NewPRService(prRepo, userRepo, newFakeTeamRepo(), fakeTx{}, common.StandardClock{}, metrics, discardLogger)
+
+	pr, _, err := svc.Create(ctx, entity.PR{ID: uuid.New(), Title: "Add search", AuthorID: authorID})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	if _, _, err := svc.ReassignReviewer(ctx, pr.ID, pr.Reviewers[0], 0); !errors.Is(err, common.ErrNoCandidate) {
+		t.Fatalf("expected ErrNoCandidate, got %v", err)
+	}
+
+	userRepo.users[r3] = entity.User{ID: r3, TeamName: teamName, Name: "R3", IsActive: true}
+	if _, _, err := svc.ReassignReviewer(ctx, pr.ID, pr.Reviewers[0], 0); err != nil {
+		t.Fatalf("ReassignReviewer returned error: %v", err)
+	}
+
+	if err := svc.RefreshOpenPRMetrics(ctx); err != nil {
+		t.Fatalf("RefreshOpenPRMetrics returned error: %v", err)
+	}
+	if got := metrics.openPRs[teamName]; got != 1 {
+		t.Errorf("open PRs gauge: got %d, want 1", got)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := svc.Merge(ctx, pr.ID, 0); err != nil {
+			t.Fatalf("Merge returned error: %v", err)
+		}
+	}
+
+	if metrics.created != 1 || metrics.reassigned != 1 || metrics.noCandidate != 1 {
+		t.Errorf("counters: created %d, reassigned %d, no candidate %d; want 1 each",
+			metrics.created, metrics.reassigned, metrics.noCandidate)
+	}
+	if metrics.merged != 1 {
+		t.Errorf("repeated merge must be counted once, got %d", metrics.merged)
+	}
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/app"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+type rbacFixture struct {
+	users *fakeUserRepo
+	teams *fakeTeamRepo
+
+	memberID, otherID, leadID, adminID, outsiderID uuid.UUID
+}
+
+func newRBACFixture() rbacFixture {
+	f := rbacFixture{
+		users:      newFakeUserRepo(),
+		teams:      newFakeTeamRepo(),
+		memberID:   uuid.New(),
+		otherID:    uuid.New(),
+		leadID:     uuid.New(),
+		adminID:    uuid.New(),
+		outsiderID: uuid.New(),
+	}
+
+	f.teams.teams[teamName] = entity.Team{Name: teamName}
+	for _, id := range []uuid.UUID{f.memberID, f.otherID, f.leadID, f.adminID} {
+		f.users.users[id] = entity.User{ID: id, TeamName: teamName, Name: id.String(), IsActive: true}
+	}
+	f.users.users[f.outsiderID] = entity.User{ID: f.outsiderID, TeamName: "frontend", Name: "Outsider", IsActive: true}
+	f.teams.roles[teamName] = map[uuid.UUID]entity.TeamRole{
+		f.leadID:  entity.RoleLead,
+		f.adminID: entity.RoleAdmin,
+	}
+
+	return f
+}
+
+// as returns a context of a signed in user, as built by the auth middleware.
+func (f rbacFixture) as(id uuid.UUID) context.Context {
+	return app.WithPrincipal(context.Background(), entity.Principal{
+		UserID:   id,
+		TeamName: f.users.users[id].TeamName,
+		Scopes:   entity.UserScopes,
+	})
+}
+
+func TestUserService_SetActive_Roles(t *testing.T) {
+	f := newRBACFixture()
+	svc := NewUserService(f.users, newFakePRRepo(), f.teams, fakeClock{})
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		target  uuid.UUID
+		wantErr error
+	}{
+		{name: "member toggles self", ctx: f.as(f.memberID), target: f.memberID},
+		{name: "member toggles other", ctx: f.as(f.memberID), target: f.otherID, wantErr: common.ErrForbidden},
+		{name: "outsider toggles member", ctx: f.as(f.outsiderID), target: f.otherID, wantErr: common.ErrForbidden},
+		{name: "lead toggles member", ctx: f.as(f.leadID), target: f.otherID},
+		{name: "admin toggles member", ctx: f.as(f.adminID), target: f.otherID},
+		{name: "service key toggles member", ctx: context.Background(), target: f.otherID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := f.users.users[tt.target].IsActive
+
+			_, err := svc.SetActive(tt.ctx, tt.target, !before)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+
+			changed := f.users.users[tt.target].IsActive != before
+			if changed != (tt.wantErr == nil) {
+				t.Fatalf("is_active changed=%v, want %v", changed, tt.wantErr == nil)
+			}
+		})
+	}
+}
+
+func TestUserService_Preferences_Roles(t *testing.T) {
+	f := newRBACFixture()
+	svc := NewUserService(f.users, newFakePRRepo(), f.teams, fakeClock{})
+
+	limit := 3
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		target  uuid.UUID
+		wantErr error
+	}{
+		{name: "member updates self", ctx: f.as(f.memberID), target: f.memberID},
+		{name: "member updates other", ctx: f.as(f.memberID), target: f.otherID, wantErr: common.ErrForbidden},
+		{name: "outsider updates member", ctx: f.as(f.outsiderID), target: f.otherID, wantErr: common.ErrForbidden},
+		{name: "lead updates member", ctx: f.as(f.leadID), target: f.otherID},
+		{name: "service key updates member", ctx: context.Background(), target: f.otherID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := f.users.users[tt.target]
+			u.Skills, u.MaxOpenReviews = nil, nil
+			f.users.users[tt.target] = u
+
+			if _, err := svc.SetSkills(tt.ctx, tt.target, []string{"go"}); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetSkills: expected %v, got %v", tt.wantErr, err)
+			}
+			if _, err := svc.SetWorkingHours(tt.ctx, tt.target, nil); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetWorkingHours: expected %v, got %v", tt.wantErr, err)
+			}
+			if _, err := svc.SetMaxOpenReviews(tt.ctx, tt.target, &limit); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetMaxOpenReviews: expected %v, got %v", tt.wantErr, err)
+			}
+
+			got := f.users.users[tt.target]
+			changed := got.MaxOpenReviews != nil || len(got.Skills) != 0
+			if changed != (tt.wantErr == nil) {
+				t.Fatalf("preferences changed=%v, want %v", changed, tt.wantErr == nil)
+			}
+		})
+	}
+}
+
+func TestUserService_Availability_Roles(t *testing.T) {
+	f := newRBACFixture()
+	svc := NewUserService(f.users, newFakePRRepo(), f.teams, fakeClock{})
+
+	starts := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	window := func(userID uuid.UUID) entity.Unavailability {
+		return entity.Unavailability{UserID: userID, StartsAt: starts, EndsAt: starts.Add(72 * time.Hour)}
+	}
+
+	if _, err := svc.AddUnavailability(f.as(f.memberID), window(f.otherID)); !errors.Is(err, common.ErrForbidden) {
+		t.Fatalf("member adding for another: expected ErrForbidden, got %v", err)
+	}
+
+	own, err := svc.AddUnavailability(f.as(f.memberID), window(f.memberID))
+	if err != nil {
+		t.Fatalf("member adding own: %v", err)
+	}
+
+	byLead, err := svc.AddUnavailability(f.as(f.leadID), window(f.otherID))
+	if err != nil {
+		t.Fatalf("lead adding for member: %v", err)
+	}
+
+	if _, err := svc.UpdateUnavailability(f.as(f.memberID), entity.Unavailability{
+		ID: byLead.ID, StartsAt: starts, EndsAt: starts.Add(time.Hour),
+	}); !errors.Is(err, common.ErrForbidden) {
+		t.Fatalf("member updating another's: expected ErrForbidden, got %v", err)
+	}
+
+	if err := svc.DeleteUnavailability(f.as(f.otherID), own.ID); !errors.Is(err, common.ErrForbidden) {
+		t.Fatalf("member deleting another's: expected ErrForbidden, got %v", err)
+	}
+	if err := svc.DeleteUnavailability(f.as(f.memberID), own.ID); err != nil {
+		t.Fatalf("member deleting own: %v", err)
+	}
+}
+
+func TestTeamService_Roles(t *testing.T) {
+	f := newRBACFixture()
+	svc := NewTeamService(f.teams, f.users, fakeTx{}, fakeClock{})
+
+	policy := entity.CapacityOverAssign
+	patch := entity.TeamSettingsPatch{CapacityPolicy: &policy}
+
+	if _, err := svc.UpdateSettings(f.as(f.memberID), teamName, patch); !errors.Is(err, common.ErrForbidden) {
+		t.Fatalf("member changing settings: expected ErrForbidden, got %v", err)
+	}
+	if _, err := svc.SetRules(f.as(f.memberID), teamName, nil); !errors.Is(err, common.ErrForbidden) {
+		t.Fatalf("member changing rules: expected ErrForbidden, got %v", err)
+	}
+	if _, err := svc.UpdateSettings(f.as(f.leadID), teamName, patch); err != nil {
+		t.Fatalf("lead changing settings: %v", err)
+	}
+
+	promote := entity.TeamMemberRole{TeamName: teamName, UserID: f.memberID, Role: entity.RoleLead}
+	if _, err := svc.SetRole(f.as(f.leadID), promote); !errors.Is(err, common.ErrForbidden) {
+		t.Fatalf("lead granting roles: expected ErrForbidden, got %v", err)
+	}
+	if _, err := svc.SetRole(f.as(f.adminID), promote); err != nil {
+		t.Fatalf("admin granting lead: %v", err)
+	}
+	if _, err := svc.UpdateSettings(f.as(f.memberID), teamName, patch); err != nil {
+		t.Fatalf("promoted member changing settings: %v", err)
+	}
+
+	if _, err := svc.SetRole(f.as(f.adminID), entity.TeamMemberRole{TeamName: teamName, UserID: f.outsiderID, Role: entity.RoleLead}); !errors.Is(err, common.ErrInvalidRole) {
+		t.Fatalf("granting a role to another team's member: expected ErrInvalidRole, got %v", err)
+	}
+	if _, ok := f.teams.roles[teamName][f.outsiderID]; ok {
+		t.Fatalf("a role must not be granted to another team's member")
+	}
+	if _, err := svc.SetRole(f.as(f.adminID), entity.TeamMemberRole{TeamName: teamName, UserID: f.memberID, Role: "owner"}); !errors.Is(err, common.ErrInvalidRole) {
+		t.Fatalf("unknown role: expected ErrInvalidRole, got %v", err)
+	}
+	if _, err := svc.SetRole(f.as(f.adminID), entity.TeamMemberRole{TeamName: teamName, UserID: f.memberID, Role: entity.RoleMember}); err != nil {
+		t.Fatalf("admin revoking lead: %v", err)
+	}
+	if _, ok := f.teams.roles[teamName][f.memberID]; ok {
+		t.Fatalf("revoked role must be removed")
+	}
+}
+
+func TestTeamService_CreateTeam_CreatorAdministers(t *testing.T) {
+	f := newRBACFixture()
+	svc := NewTeamService(f.teams, f.users, fakeTx{}, fakeClock{})
+
+	if _, _, err := svc.CreateTeam(f.as(f.outsiderID), "mobile", []entity.User{{ID: uuid.New(), Name: "Dev", IsActive: true}}); err != nil {
+		t.Fatalf("CreateTeam returned error: %v", err)
+	}
+
+	if role := f.teams.roles["mobile"][f.outsiderID]; role != entity.RoleAdmin {
+		t.Fatalf("creator role: got %q, want admin", role)
+	}
+}
@@ -0,0 +1,376 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+const maxReviewers = 2
+
+type selection struct {
+	teamName     string
+	authorID     uuid.UUID
+	changedFiles []string
+	requiredTags []string
+	// keep are reviewers staying on the PR: they are not candidates, but their skills and rules they satisfy count.
+	keep    []uuid.UUID
+	exclude []uuid.UUID
+	slots   int
+}
+
+type candidate struct {
+	user       entity.User
+	order      int
+	ownerRank  int
+	ownedPaths []string
+	// inHours is false only for users with a working-hours profile who are off work right now.
+	inHours bool
+	load    int
+}
+
+func (c candidate) isOwner() bool { return c.ownerRank >= 0 }
+
+// gain is what picking a candidate adds to the current selection.
+type gain struct {
+	rules []entity.TeamRule
+	tags  []string
+}
+
+// selectReviewers picks up to sel.slots active and currently available members of sel.teamName
+// and records why every member was or was not considered.
+// Team "never" rules remove candidates up front, and every applicable requirement rule must be met
+// by the kept and picked reviewers, otherwise ErrRulesUnsatisfied is returned.
+// Members at their review capacity are skipped; when nobody else is left,
+// the team capacity policy either over-assigns the least loaded of them or fails with ErrNoCandidate.
+// Each pick meets as many open requirement rules as possible,
+// then covers as many still uncovered required tags as possible,
+// then, if the team prefers it, reviewers inside their working hours win,
+// then code owners of the changed files, then the team pool order (by name) decides.
+func (s *PRService) selectReviewers(ctx context.Context, sel selection) (entity.AssignmentDecision, error) {
+	ctx, span := tracer.Start(ctx, "PRService.selectReviewers")
+	defer span.End()
+
+	members, err := s.users.ListByTeamName(ctx, sel.teamName)
+	if err != nil {
+		return entity.AssignmentDecision{}, err
+	}
+
+	now := s.clock.Now()
+
+	unavailable, err := s.users.ListUnavailableUserIDs(ctx, sel.teamName, now)
+	if err != nil {
+		return entity.AssignmentDecision{}, err
+	}
+
+	rules, err := s.teams.GetRules(ctx, sel.teamName)
+	if err != nil {
+		return entity.AssignmentDecision{}, err
+	}
+
+	ownerRank, ownedPaths, err := s.codeOwnersOf(ctx, sel.teamName, sel.changedFiles)
+	if err != nil {
+		return entity.AssignmentDecision{}, err
+	}
+
+	settings, err := s.teams.GetSettings(ctx, sel.teamName)
+	if err != nil {
+		return entity.AssignmentDecision{}, err
+	}
+
+	loads, err := s.prs.CountOpenReviews(ctx, sel.teamName)
+	if err != nil {
+		return entity.AssignmentDecision{}, err
+	}
+
+	excluded := make(map[uuid.UUID]string, len(sel.exclude)+len(sel.keep)+len(unavailable)+1)
+	excluded[sel.authorID] = "author of the PR"
+	for _, id := range sel.exclude {
+		excluded[id] = "being replaced"
+	}
+	for _, id := range sel.keep {
+		excluded[id] = "already assigned"
+	}
+	for _, id := range unavailable {
+		if _, ok := excluded[id]; !ok {
+			excluded[id] = "out of office"
+		}
+	}
+	for id, rule := range rules.Forbidden(sel.authorID) {
+		if _, ok := excluded[id]; !ok {
+			excluded[id] = "team rule: " + rule.String()
+		}
+	}
+
+	var decision entity.AssignmentDecision
+
+	pool := make([]candidate, 0, len(members))
+	var full []candidate
+	for i, u := range members {
+		if reason, skip := excluded[u.ID]; skip {
+			decision.Excluded = append(decision.Excluded, entity.ExcludedCandidate{UserID: u.ID, Reason: reason})
+			continue
+		}
+		if !u.IsActive {
+			decision.Excluded = append(decision.Excluded, entity.ExcludedCandidate{UserID: u.ID, Reason: "inactive"})
+			continue
+		}
+
+		c := candidate{user: u, order: i, ownerRank: -1, inHours: true, load: loads[u.ID]}
+		if settings.PreferWorkingHours && u.WorkingHours != nil {
+			c.inHours = u.WorkingHours.Contains(now)
+		}
+		if rank, ok := ownerRank[u.ID]; ok {
+			c.ownerRank = rank
+			c.ownedPaths = ownedPaths[u.ID]
+		}
+
+		if u.AtCapacity(c.load) {
+			full = append(full, c)
+			continue
+		}
+		pool = append(pool, c)
+	}
+
+	overAssign := false
+	if len(pool) == 0 && len(full) > 0 {
+		if settings.CapacityPolicy != entity.CapacityOverAssign {
+			return entity.AssignmentDecision{}, common.ErrNoCandidate
+		}
+		pool, overAssign = full, true
+	} else {
+		for _, c := range full {
+			decision.Excluded = append(decision.Excluded, entity.ExcludedCandidate{
+				UserID: c.user.ID,
+				Reason: fmt.Sprintf("at capacity (%d open reviews, limit %d)", c.load, *c.user.MaxOpenReviews),
+			})
+		}
+	}
+
+	for _, c := range pool {
+		decision.Pool = append(decision.Pool, c.user.ID)
+	}
+
+	kept, err := s.keptReviewers(ctx, sel.keep)
+	if err != nil {
+		return entity.AssignmentDecision{}, err
+	}
+
+	requirements := rules.Requirements(sel.authorID)
+	uncovered := uncoveredTags(sel.requiredTags, kept)
+	unmet := unmetRules(requirements, kept)
+
+	decision.Strategy = strategyOf(len(requirements) > 0, overAssign, len(sel.requiredTags) > 0,
+		settings.PreferWorkingHours, len(ownerRank) > 0)
+
+	for len(decision.Chosen) < sel.slots && len(pool) > 0 {
+		best := 0
+		bestGain := gainOf(pool[0].user, unmet, uncovered)
+		for i := 1; i < len(pool); i++ {
+			g := gainOf(pool[i].user, unmet, uncovered)
+			if better(pool[i], g, pool[best], bestGain, overAssign) {
+				best, bestGain = i, g
+			}
+		}
+
+		c := pool[best]
+		pool = append(pool[:best], pool[best+1:]...)
+
+		for _, t := range bestGain.tags {
+			delete(uncovered, t)
+		}
+		unmet = unmetRules(unmet, []entity.User{c.user})
+
+		decision.Chosen = append(decision.Chosen, entity.ReviewerChoice{
+			UserID:  c.user.ID,
+			Reasons: choiceReasons(c, bestGain, settings.PreferWorkingHours, overAssign),
+			Score: entity.ChoiceScore{
+				RulesMet:       len(bestGain.rules),
+				TagsCovered:    len(bestGain.tags),
+				InWorkingHours: c.inHours,
+				CodeOwnerRank:  c.ownerRank,
+				OpenReviews:    c.load,
+				PoolOrder:      c.order,
+			},
+		})
+	}
+
+	if len(unmet) > 0 {
+		descs := make([]string, 0, len(unmet))
+		for _, r := range unmet {
+			descs = append(descs, r.String())
+		}
+		return entity.AssignmentDecision{}, fmt.Errorf("%w: %s", common.ErrRulesUnsatisfied, strings.Join(descs, "; "))
+	}
+
+	return decision, nil
+}
+
+// strategyOf lists the criteria that ordered candidates, most significant first.
+func strategyOf(rules, byLoad, tags, workingHours, codeOwners bool) string {
+	var criteria []string
+	if rules {
+		criteria = append(criteria, "team_rules")
+	}
+	if byLoad {
+		criteria = append(criteria, "least_loaded")
+	}
+	if tags {
+		criteria = append(criteria, "required_tags")
+	}
+	if workingHours {
+		criteria = append(criteria, "working_hours")
+	}
+	if codeOwners {
+		criteria = append(criteria, "code_owners")
+	}
+	criteria = append(criteria, "pool_order")
+	return strings.Join(criteria, " > ")
+}
+
+func better(c candidate, g gain, best candidate, bestGain gain, byLoad bool) bool {
+	if len(g.rules) != len(bestGain.rules) {
+		return len(g.rules) > len(bestGain.rules)
+	}
+	if byLoad && c.load != best.load {
+		return c.load < best.load
+	}
+	if len(g.tags) != len(bestGain.tags) {
+		return len(g.tags) > len(bestGain.tags)
+	}
+	if c.inHours != best.inHours {
+		return c.inHours
+	}
+	if c.isOwner() != best.isOwner() {
+		return c.isOwner()
+	}
+	if c.isOwner() && c.ownerRank != best.ownerRank {
+		return c.ownerRank < best.ownerRank
+	}
+	return c.order < best.order
+}
+
+func choiceReasons(c candidate, g gain, preferWorkingHours, overAssigned bool) []string {
+	var reasons []string
+
+	for _, r := range g.rules {
+		reasons = append(reasons, "satisfies team rule: "+r.String())
+	}
+	if overAssigned {
+		reasons = append(reasons, fmt.Sprintf("everyone is at capacity, least loaded (%d open reviews, limit %d)", c.load, *c.user.MaxOpenReviews))
+	}
+	if len(g.tags) > 0 {
+		reasons = append(reasons, "covers required tags: "+strings.Join(g.tags, ", "))
+	}
+	if preferWorkingHours && c.user.WorkingHours != nil {
+		if c.inHours {
+			reasons = append(reasons, "inside working hours ("+c.user.WorkingHours.String()+")")
+		} else {
+			reasons = append(reasons, "outside working hours ("+c.user.WorkingHours.String()+")")
+		}
+	}
+	if c.isOwner() {
+		reasons = append(reasons, "code owner of "+strings.Join(c.ownedPaths, ", "))
+	}
+	if len(reasons) == 0 {
+		reasons = append(reasons, "available member of team "+c.user.TeamName)
+	}
+
+	return reasons
+}
+
+func gainOf(u entity.User, unmet []entity.TeamRule, uncovered map[string]struct{}) gain {
+	var g gain
+	for _, r := range unmet {
+		if r.SatisfiedBy(u) {
+			g.rules = append(g.rules, r)
+		}
+	}
+	g.tags = coveredTags(u, uncovered)
+	return g
+}
+
+func coveredTags(u entity.User, uncovered map[string]struct{}) []string {
+	var res []string
+	for _, s := range u.Skills {
+		if _, ok := uncovered[s]; ok {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+func uncoveredTags(tags []string, kept []entity.User) map[string]struct{} {
+	uncovered := make(map[string]struct{}, len(tags))
+	for _, t := range tags {
+		uncovered[t] = struct{}{}
+	}
+
+	for _, u := range kept {
+		for _, t := range coveredTags(u, uncovered) {
+			delete(uncovered, t)
+		}
+	}
+
+	return uncovered
+}
+
+func unmetRules(rules []entity.TeamRule, reviewers []entity.User) []entity.TeamRule {
+	var res []entity.TeamRule
+	for _, r := range rules {
+		met := false
+		for _, u := range reviewers {
+			if r.SatisfiedBy(u) {
+				met = true
+				break
+			}
+		}
+		if !met {
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+func (s *PRService) keptReviewers(ctx context.Context, ids []uuid.UUID) ([]entity.User, error) {
+	res := make([]entity.User, 0, len(ids))
+
+	for _, id := range ids {
+		u, err := s.users.GetByID(ctx, id)
+		if errors.Is(err, common.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, u)
+	}
+
+	return res, nil
+}
+
+func (s *PRService) codeOwnersOf(ctx context.Context, teamName string, files []string) (map[uuid.UUID]int, map[uuid.UUID][]string, error) {
+	rank := make(map[uuid.UUID]int)
+
+	if len(files) == 0 {
+		return rank, nil, nil
+	}
+
+	owners, err := s.teams.GetCodeOwners(ctx, teamName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	order, owned := owners.OwnersOf(files)
+	for i, id := range order {
+		rank[id] = i
+	}
+
+	return rank, owned, nil
+}
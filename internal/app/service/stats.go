@@ -2,19 +2,194 @@ package service
 
 import (
 	"context"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/Desnn1ch/pr-reviewer-service/internal/app"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
 	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
 )
 
 type StatsService struct {
-	prs app.PRRepo
+	prs   app.PRRepo
+	teams app.TeamRepo
+	users app.UserRepo
+}
+
+func NewStatsService(prs app.PRRepo, teams app.TeamRepo, users app.UserRepo) *StatsService {
+	return &StatsService{prs: prs, teams: teams, users: users}
+}
+
+func (s *StatsService) ReviewerStats(ctx context.Context, teamName string, includeInactive bool, sortBy entity.ReviewerStatsSort) ([]entity.ReviewerStats, error) {
+	stats, err := s.prs.ListReviewerStats(ctx, teamName, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.SortReviewerStats(stats, sortBy)
+
+	return stats, nil
 }
 
-func NewStatsService(prs app.PRRepo) *StatsService {
-	return &StatsService{prs: prs}
+// UserStats returns the user's review profile over all time.
+func (s *StatsService) UserStats(ctx context.Context, userID uuid.UUID) (entity.UserStats, error) {
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return entity.UserStats{}, err
+	}
+
+	reviews, err := s.prs.GetReviewTotals(ctx, userID)
+	if err != nil {
+		return entity.UserStats{}, err
+	}
+
+	authored, err := s.prs.GetAuthorTotals(ctx, userID)
+	if err != nil {
+		return entity.UserStats{}, err
+	}
+
+	return entity.UserStats{
+		UserID:   u.ID,
+		Username: u.Name,
+		TeamName: u.TeamName,
+		IsActive: u.IsActive,
+		Reviews:  reviews,
+		Authored: authored,
+	}, nil
 }
 
-func (s *StatsService) ReviewerStats(ctx context.Context, teamName string) ([]entity.ReviewerStats, error) {
-	return s.prs.ListReviewerStats(ctx, teamName)
+// TeamStats reports review history of the team within [from, to): time-to-merge of PRs merged
+// in the range, weekly throughput and per-reviewer time-to-first-review.
+func (s *StatsService) TeamStats(ctx context.Context, teamName string, from, to time.Time) (entity.TeamStats, error) {
+	if !from.Before(to) {
+		return entity.TeamStats{}, common.ErrInvalidInterval
+	}
+
+	if _, err := s.teams.GetByName(ctx, teamName); err != nil {
+		return entity.TeamStats{}, err
+	}
+
+	prs, err := s.prs.ListTeamPRTimes(ctx, teamName, from, to)
+	if err != nil {
+		return entity.TeamStats{}, err
+	}
+
+	reviews, err := s.prs.ListFirstReviews(ctx, teamName, from, to)
+	if err != nil {
+		return entity.TeamStats{}, err
+	}
+
+	res := entity.TeamStats{
+		TeamName: teamName,
+		From:     from,
+		To:       to,
+	}
+
+	weeks := make(map[time.Time]*entity.WeeklyThroughput)
+	for w := entity.WeekStart(from); w.Before(to); w = w.AddDate(0, 0, 7) {
+		res.Throughput = append(res.Throughput, entity.WeeklyThroughput{WeekStart: w})
+	}
+	for i := range res.Throughput {
+		weeks[res.Throughput[i].WeekStart] = &res.Throughput[i]
+	}
+
+	inRange := func(t time.Time) bool { return !t.Before(from) && t.Before(to) }
+
+	var toMerge []time.Duration
+	for _, pr := range prs {
+		if inRange(pr.CreatedAt) {
+			weeks[entity.WeekStart(pr.CreatedAt)].Opened++
+		}
+		if pr.MergedAt != nil && inRange(*pr.MergedAt) {
+			weeks[entity.WeekStart(*pr.MergedAt)].Merged++
+			toMerge = append(toMerge, pr.MergedAt.Sub(pr.CreatedAt))
+		}
+	}
+	res.TimeToMerge = entity.SummarizeDurations(toMerge)
+
+	var (
+		order   []uuid.UUID
+		names   = make(map[uuid.UUID]string)
+		latency = make(map[uuid.UUID][]time.Duration)
+	)
+	for _, r := range reviews {
+		if _, ok := latency[r.ReviewerID]; !ok {
+			order = append(order, r.ReviewerID)
+			names[r.ReviewerID] = r.Username
+		}
+		latency[r.ReviewerID] = append(latency[r.ReviewerID], r.ReviewedAt.Sub(r.AssignedAt))
+	}
+	for _, id := range order {
+		res.Reviewers = append(res.Reviewers, entity.ReviewerLatency{
+			UserID:      id,
+			Username:    names[id],
+			FirstReview: entity.SummarizeDurations(latency[id]),
+		})
+	}
+
+	return res, nil
+}
+
+// Fairness compares how many assignments each member got within [from, to) with a share
+// proportional to the time they were active and not out of office.
+func (s *StatsService) Fairness(ctx context.Context, teamName string, from, to time.Time) (entity.FairnessReport, error) {
+	if !from.Before(to) {
+		return entity.FairnessReport{}, common.ErrInvalidInterval
+	}
+
+	if _, err := s.teams.GetByName(ctx, teamName); err != nil {
+		return entity.FairnessReport{}, err
+	}
+
+	members, err := s.users.ListByTeamName(ctx, teamName)
+	if err != nil {
+		return entity.FairnessReport{}, err
+	}
+
+	changes, err := s.users.ListActivityChanges(ctx, teamName, to)
+	if err != nil {
+		return entity.FairnessReport{}, err
+	}
+
+	leaves, err := s.users.ListTeamUnavailability(ctx, teamName, from, to)
+	if err != nil {
+		return entity.FairnessReport{}, err
+	}
+
+	counts, err := s.prs.CountAssignments(ctx, teamName, from, to)
+	if err != nil {
+		return entity.FairnessReport{}, err
+	}
+
+	changesOf := make(map[uuid.UUID][]entity.ActivityChange)
+	for _, c := range changes {
+		changesOf[c.UserID] = append(changesOf[c.UserID], c)
+	}
+	leavesOf := make(map[uuid.UUID][]entity.Unavailability)
+	for _, l := range leaves {
+		leavesOf[l.UserID] = append(leavesOf[l.UserID], l)
+	}
+
+	report := entity.FairnessReport{
+		TeamName: teamName,
+		From:     from,
+		To:       to,
+		Members:  make([]entity.MemberFairness, 0, len(members)),
+	}
+
+	for _, u := range members {
+		available := entity.AvailableTime(u, changesOf[u.ID], leavesOf[u.ID], from, to)
+		report.Members = append(report.Members, entity.MemberFairness{
+			UserID:        u.ID,
+			Username:      u.Name,
+			IsActive:      u.IsActive,
+			AvailableDays: available.Hours() / 24,
+			Assignments:   counts[u.ID],
+		})
+	}
+
+	report.Finalize()
+
+	return report, nil
 }
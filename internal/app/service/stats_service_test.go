@@ -0,0 +1,412 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+func TestStatsService_TeamStats(t *testing.T) {
+	ctx := context.Background()
+
+	prRepo := newFakePRRepo()
+	teamRepo := newFakeTeamRepo()
+	teamRepo.teams[teamName] = entity.Team{Name: teamName}
+
+	// Monday, 5 October 2026.
+	from := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 14)
+
+	fast := uuid.New()
+	slow := uuid.New()
+	moved := uuid.New()
+
+	merged := func(created time.Time, took time.Duration) entity.PR {
+		at := created.Add(took)
+		return entity.PR{ID: uuid.New(), Status: entity.StatusMerged, CreatedAt: created, MergedAt: &at}
+	}
+
+	prs := []entity.PR{
+		merged(from.Add(time.Hour), 2*time.Hour),
+		merged(from.Add(2*time.Hour), 4*time.Hour),
+		merged(from.AddDate(0, 0, 8), 10*time.Hour),
+		// Created before the range, merged inside it: counts for merge time but not as opened.
+		merged(from.Add(-48*time.Hour), 50*time.Hour),
+		{ID: uuid.New(), Status: entity.StatusOpen, CreatedAt: from.AddDate(0, 0, 9), Reviewers: []uuid.UUID{fast, slow}},
+	}
+	for _, pr := range prs {
+		prRepo.prs[pr.ID] = pr
+	}
+
+	open := prs[4]
+	prRepo.events = []entity.PREvent{
+		{ID: uuid.New(), PRID: open.ID, Kind: entity.EventReviewed, ReviewerID: fast, CreatedAt: open.CreatedAt.Add(30 * time.Minute)},
+		{ID: uuid.New(), PRID: open.ID, Kind: entity.EventReviewed, ReviewerID: fast, CreatedAt: open.CreatedAt.Add(5 * time.Hour)},
+		{ID: uuid.New(), PRID: open.ID, Kind: entity.EventReviewed, ReviewerID: slow, CreatedAt: open.CreatedAt.Add(26 * time.Hour)},
+		// Reviewed, then reassigned away: the review still counts from the original assignment.
+		{ID: uuid.New(), PRID: open.ID, Kind: entity.EventReviewed, ReviewerID: moved, CreatedAt: open.CreatedAt.Add(3 * time.Hour)},
+	}
+	prRepo.decisions = []entity.AssignmentDecision{
+		{ID: uuid.New(), PRID: open.ID, Kind: entity.AssignmentCreate, Chosen: []entity.ReviewerChoice{{UserID: moved}}, CreatedAt: open.CreatedAt.Add(time.Hour)},
+	}
+
+	svc := NewStatsService(prRepo, teamRepo, newFakeUserRepo())
+
+	stats, err := svc.TeamStats(ctx, teamName, from, to)
+	if err != nil {
+		t.Fatalf("TeamStats returned error: %v", err)
+	}
+
+	want := entity.DurationSummary{Count: 4, Median: 4 * time.Hour, P90: 50 * time.Hour}
+	if stats.TimeToMerge != want {
+		t.Fatalf("time to merge: got %+v, want %+v", stats.TimeToMerge, want)
+	}
+
+	if len(stats.Throughput) != 2 {
+		t.Fatalf("expected 2 weeks, got %+v", stats.Throughput)
+	}
+	if w := stats.Throughput[0]; !w.WeekStart.Equal(from) || w.Opened != 2 || w.Merged != 3 {
+		t.Fatalf("unexpected first week %+v", w)
+	}
+	if w := stats.Throughput[1]; w.Opened != 2 || w.Merged != 1 {
+		t.Fatalf("unexpected second week %+v", w)
+	}
+
+	latency := make(map[uuid.UUID]time.Duration)
+	for _, r := range stats.Reviewers {
+		latency[r.UserID] = r.FirstReview.Median
+	}
+	if latency[fast] != 30*time.Minute || latency[slow] != 26*time.Hour || latency[moved] != 2*time.Hour {
+		t.Fatalf("unexpected time to first review %+v", stats.Reviewers)
+	}
+
+	if _, err := svc.TeamStats(ctx, teamName, to, from); !errors.Is(err, common.ErrInvalidInterval) {
+		t.Fatalf("expected ErrInvalidInterval, got %v", err)
+	}
+	if _, err := svc.TeamStats(ctx, "unknown", from, to); !errors.Is(err, common.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestPRService_RecordReview_StopsOverdue(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	userRepo := newFakeUserRepo()
+	prRepo := newFakePRRepo()
+	teamRepo := newFakeTeamRepo()
+	teamRepo.teams[teamName] = entity.Team{Name: teamName}
+	teamRepo.settings[teamName] = entity.TeamSettings{TeamName: teamName, CapacityPolicy: entity.CapacityStrict, ReviewSLA: time.Hour}
+
+	authorID := uuid.New()
+	reviewer := uuid.New()
+	outsider := uuid.New()
+
+	prID := uuid.New()
+	prRepo.prs[prID] = entity.PR{ID: prID, AuthorID: authorID, Status: entity.StatusOpen, CreatedAt: now.Add(-3 * time.Hour), Reviewers: []uuid.UUID{reviewer}}
+
+	svc := NewPRService(prRepo, userRepo, teamRepo, fakeTx{}, fakeClock{now: now}, &fakeMetrics{}, discardLogger)
+
+	if _, err := svc.RecordReview(ctx, prID, outsider); !errors.Is(err, common.ErrNotAssigned) {
+		t.Fatalf("expected ErrNotAssigned, got %v", err)
+	}
+
+	ev, err := svc.RecordReview(ctx, prID, reviewer)
+	if err != nil {
+		t.Fatalf("RecordReview returned error: %v", err)
+	}
+	if ev.Kind != entity.EventReviewed || !ev.CreatedAt.Equal(now) {
+		t.Fatalf("unexpected event %+v", ev)
+	}
+
+	overdue, err := svc.ListOverdue(ctx, teamName)
+	if err != nil || len(overdue) != 0 {
+		t.Fatalf("reviewed assignments must not be overdue, got %+v, %v", overdue, err)
+	}
+}
+
+func TestStatsService_Fairness(t *testing.T) {
+	ctx := context.Background()
+
+	prRepo := newFakePRRepo()
+	userRepo := newFakeUserRepo()
+	teamRepo := newFakeTeamRepo()
+	teamRepo.teams[teamName] = entity.Team{Name: teamName}
+
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 10)
+
+	always := uuid.New()
+	late := uuid.New()
+	away := uuid.New()
+
+	userRepo.users[always] = entity.User{ID: always, TeamName: teamName, Name: "Always", IsActive: true}
+	userRepo.users[late] = entity.User{ID: late, TeamName: teamName, Name: "Late", IsActive: true}
+	userRepo.users[away] = entity.User{ID: away, TeamName: teamName, Name: "Away", IsActive: true}
+
+	// Late was inactive until the middle of the period, Away spent its second half out of office.
+	userRepo.changes = []entity.ActivityChange{{UserID: late, IsActive: true, ChangedAt: from.AddDate(0, 0, 5)}}
+	leave := entity.Unavailability{ID: uuid.New(), UserID: away, StartsAt: from.AddDate(0, 0, 5), EndsAt: to.AddDate(0, 0, 3)}
+	userRepo.leaves[leave.ID] = leave
+
+	assign := func(day int, ids ...uuid.UUID) {
+		d := entity.AssignmentDecision{ID: uuid.New(), CreatedAt: from.AddDate(0, 0, day)}
+		for _, id := range ids {
+			d.Chosen = append(d.Chosen, entity.ReviewerChoice{UserID: id})
+		}
+		prRepo.decisions = append(prRepo.decisions, d)
+	}
+	assign(0, always, away)
+	assign(1, always, away)
+	assign(6, always, late)
+	assign(7, always, late)
+	assign(8, always)
+	assign(-1, always, late) // before the period
+	prRepo.events = append(prRepo.events, entity.PREvent{ID: uuid.New(), Kind: entity.EventEscalationLeadAdded, NewReviewerID: always, CreatedAt: from.AddDate(0, 0, 9)})
+
+	svc := NewStatsService(prRepo, teamRepo, userRepo)
+
+	report, err := svc.Fairness(ctx, teamName, from, to)
+	if err != nil {
+		t.Fatalf("Fairness returned error: %v", err)
+	}
+
+	if report.Total != 10 {
+		t.Fatalf("total: got %d, want 10", report.Total)
+	}
+
+	byID := make(map[uuid.UUID]entity.MemberFairness)
+	for _, m := range report.Members {
+		byID[m.UserID] = m
+	}
+
+	check := func(id uuid.UUID, days float64, assignments int, ideal float64) {
+		t.Helper()
+		m := byID[id]
+		if m.AvailableDays != days || m.Assignments != assignments || m.IdealShare != ideal {
+			t.Errorf("%s: got days %v, assignments %d, ideal %v", m.Username, m.AvailableDays, m.Assignments, m.IdealShare)
+		}
+		if m.Deviation != float64(assignments)-ideal {
+			t.Errorf("%s: deviation %v", m.Username, m.Deviation)
+		}
+	}
+	check(always, 10, 6, 5)
+	check(late, 5, 2, 2.5)
+	check(away, 5, 2, 2.5)
+
+	if report.MaxMinRatio == nil || math.Abs(*report.MaxMinRatio-1.5) > 1e-9 {
+		t.Fatalf("max/min ratio: got %v, want 1.5", report.MaxMinRatio)
+	}
+	if report.Gini < 0.095 || report.Gini > 0.096 {
+		t.Fatalf("gini: got %v, want ~0.0952", report.Gini)
+	}
+}
+
+func TestStatsService_Fairness_ChangesAfterPeriod(t *testing.T) {
+	userRepo := newFakeUserRepo()
+	teamRepo := newFakeTeamRepo()
+	teamRepo.teams[teamName] = entity.Team{Name: teamName}
+
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 10)
+
+	left := uuid.New()
+	back := uuid.New()
+
+	// Left was active through the period and deactivated afterwards; Back is the reverse.
+	userRepo.users[left] = entity.User{ID: left, TeamName: teamName, Name: "Left", IsActive: false}
+	userRepo.users[back] = entity.User{ID: back, TeamName: teamName, Name: "Back", IsActive: true}
+	userRepo.changes = []entity.ActivityChange{
+		{UserID: left, IsActive: false, ChangedAt: to.AddDate(0, 0, 2)},
+		{UserID: back, IsActive: true, ChangedAt: to.AddDate(0, 0, 2)},
+		{UserID: back, IsActive: false, ChangedAt: to.AddDate(0, 0, 3)},
+		{UserID: back, IsActive: true, ChangedAt: to.AddDate(0, 0, 4)},
+	}
+
+	svc := NewStatsService(newFakePRRepo(), teamRepo, userRepo)
+
+	report, err := svc.Fairness(context.Background(), teamName, from, to)
+	if err != nil {
+		t.Fatalf("Fairness returned error: %v", err)
+	}
+
+	for _, m := range report.Members {
+		want := map[uuid.UUID]float64{left: 10, back: 0}[m.UserID]
+		if m.AvailableDays != want {
+			t.Errorf("%s: available days got %v, want %v", m.Username, m.AvailableDays, want)
+		}
+	}
+}
+
+func TestStatsService_Fairness_JoinedDuringPeriod(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newFakeUserRepo()
+	teamRepo := newFakeTeamRepo()
+
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 10)
+
+	veteran := uuid.New()
+	newcomer := uuid.New()
+
+	teams := NewTeamService(teamRepo, userRepo, fakeTx{}, fakeClock{now: from.AddDate(0, 0, 4)})
+	if _, _, err := teams.CreateTeam(ctx, teamName, []entity.User{{ID: newcomer, Name: "Newcomer", IsActive: true}}); err != nil {
+		t.Fatalf("CreateTeam returned error: %v", err)
+	}
+	// Veteran was a member long before the period and never changed.
+	userRepo.users[veteran] = entity.User{ID: veteran, TeamName: teamName, Name: "Veteran", IsActive: true}
+
+	if _, err := NewUserService(userRepo, newFakePRRepo(), teamRepo, fakeClock{now: from.AddDate(0, 0, 6)}).SetActive(ctx, newcomer, false); err != nil {
+		t.Fatalf("SetActive returned error: %v", err)
+	}
+	if _, err := NewUserService(userRepo, newFakePRRepo(), teamRepo, fakeClock{now: from.AddDate(0, 0, 8)}).SetActive(ctx, newcomer, true); err != nil {
+		t.Fatalf("SetActive returned error: %v", err)
+	}
+
+	svc := NewStatsService(newFakePRRepo(), teamRepo, userRepo)
+
+	report, err := svc.Fairness(ctx, teamName, from, to)
+	if err != nil {
+		t.Fatalf("Fairness returned error: %v", err)
+	}
+
+	for _, m := range report.Members {
+		want := map[uuid.UUID]float64{veteran: 10, newcomer: 4}[m.UserID]
+		if m.AvailableDays != want {
+			t.Errorf("%s: available days got %v, want %v", m.Username, m.AvailableDays, want)
+		}
+	}
+}
+
+func TestStatsService_ReviewerStats_ZeroLoadAndSorting(t *testing.T) {
+	ctx := context.Background()
+
+	prRepo := newFakePRRepo()
+
+	last := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	busy := entity.ReviewerStats{UserID: uuid.New(), Username: "Busy", TeamName: teamName, IsActive: true, AssignedOpenPRs: 3, TotalReviews: 4, MergedReviews: 1, LastAssignedAt: &last}
+	idle := entity.ReviewerStats{UserID: uuid.New(), Username: "Idle", TeamName: teamName, IsActive: true}
+	veteran := entity.ReviewerStats{UserID: uuid.New(), Username: "Veteran", TeamName: teamName, IsActive: true, AssignedOpenPRs: 1, TotalReviews: 9, MergedReviews: 8}
+	gone := entity.ReviewerStats{UserID: uuid.New(), Username: "Gone", TeamName: teamName, IsActive: false, TotalReviews: 2, MergedReviews: 2}
+	prRepo.reviewerStats = []entity.ReviewerStats{idle, gone, veteran, busy}
+
+	svc := NewStatsService(prRepo, newFakeTeamRepo(), newFakeUserRepo())
+
+	names := func(stats []entity.ReviewerStats) []string {
+		var res []string
+		for _, s := range stats {
+			res = append(res, s.Username)
+		}
+		return res
+	}
+
+	tests := []struct {
+		name            string
+		includeInactive bool
+		sortBy          entity.ReviewerStatsSort
+		want            []string
+	}{
+		{name: "open load, zero-load members included", sortBy: entity.SortByOpen, want: []string{"Busy", "Veteran", "Idle"}},
+		{name: "total reviews with inactive", includeInactive: true, sortBy: entity.SortByTotal, want: []string{"Veteran", "Busy", "Gone", "Idle"}},
+		{name: "merged reviews", sortBy: entity.SortByMerged, want: []string{"Veteran", "Busy", "Idle"}},
+		{name: "last assigned, never assigned last", sortBy: entity.SortByLastAssigned, want: []string{"Busy", "Idle", "Veteran"}},
+		{name: "name", includeInactive: true, sortBy: entity.SortByName, want: []string{"Busy", "Gone", "Idle", "Veteran"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := svc.ReviewerStats(ctx, teamName, tt.includeInactive, tt.sortBy)
+			if err != nil {
+				t.Fatalf("ReviewerStats returned error: %v", err)
+			}
+			got := names(stats)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestStatsService_UserStats(t *testing.T) {
+	ctx := context.Background()
+
+	prRepo := newFakePRRepo()
+	userRepo := newFakeUserRepo()
+
+	alice := entity.User{ID: uuid.New(), Name: "Alice", TeamName: teamName, IsActive: true}
+	bob := entity.User{ID: uuid.New(), Name: "Bob", TeamName: teamName, IsActive: true}
+	userRepo.users[alice.ID] = alice
+	userRepo.users[bob.ID] = bob
+
+	created := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	merged1 := created.Add(2 * time.Hour)
+	merged2 := created.Add(6 * time.Hour)
+
+	prs := []entity.PR{
+		{ID: uuid.New(), AuthorID: bob.ID, Status: entity.StatusOpen, CreatedAt: created, Reviewers: []uuid.UUID{alice.ID}},
+		{ID: uuid.New(), AuthorID: bob.ID, Status: entity.StatusMerged, CreatedAt: created, MergedAt: &merged1, Reviewers: []uuid.UUID{alice.ID}},
+		{ID: uuid.New(), AuthorID: bob.ID, Status: entity.StatusMerged, CreatedAt: created, MergedAt: &merged2, Reviewers: []uuid.UUID{alice.ID}},
+		{ID: uuid.New(), AuthorID: alice.ID, Status: entity.StatusOpen, CreatedAt: created, Reviewers: []uuid.UUID{bob.ID}},
+	}
+	for _, pr := range prs {
+		prRepo.prs[pr.ID] = pr
+	}
+	prRepo.decisions = []entity.AssignmentDecision{
+		{ID: uuid.New(), PRID: prs[3].ID, Kind: entity.AssignmentReassign, ReplacedUserID: alice.ID},
+	}
+
+	svc := NewStatsService(prRepo, newFakeTeamRepo(), userRepo)
+
+	got, err := svc.UserStats(ctx, alice.ID)
+	if err != nil {
+		t.Fatalf("UserStats returned error: %v", err)
+	}
+
+	if got.Username != "Alice" || got.TeamName != teamName {
+		t.Errorf("user: got %q/%q", got.Username, got.TeamName)
+	}
+	if got.Reviews.OpenAssignments != 1 {
+		t.Errorf("open assignments: got %d, want 1", got.Reviews.OpenAssignments)
+	}
+	if got.Reviews.ReassignedAway != 1 {
+		t.Errorf("reassigned away: got %d, want 1", got.Reviews.ReassignedAway)
+	}
+	if got.Reviews.TotalAssignments != 4 {
+		t.Errorf("total assignments: got %d, want 4", got.Reviews.TotalAssignments)
+	}
+	if got.Reviews.AvgOpenTime == nil || *got.Reviews.AvgOpenTime != 4*time.Hour {
+		t.Errorf("avg open time: got %v, want 4h", got.Reviews.AvgOpenTime)
+	}
+	if got.Authored.Authored != 1 || got.Authored.Merged != 0 {
+		t.Errorf("authored: got %+v, want 1 authored, 0 merged", got.Authored)
+	}
+
+	bobStats, err := svc.UserStats(ctx, bob.ID)
+	if err != nil {
+		t.Fatalf("UserStats returned error: %v", err)
+	}
+	if bobStats.Reviews.AvgOpenTime != nil {
+		t.Errorf("avg open time without merged reviews: got %v, want nil", *bobStats.Reviews.AvgOpenTime)
+	}
+	if bobStats.Authored.Authored != 3 || bobStats.Authored.Merged != 2 {
+		t.Errorf("authored: got %+v, want 3 authored, 2 merged", bobStats.Authored)
+	}
+
+	if _, err := svc.UserStats(ctx, uuid.New()); !errors.Is(err, common.ErrNotFound) {
+		t.Fatalf("unknown user: got %v, want ErrNotFound", err)
+	}
+}
@@ -3,6 +3,9 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
 
 	"github.com/Desnn1ch/pr-reviewer-service/internal/app"
 	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
@@ -13,17 +16,22 @@ type TeamService struct {
 	teams app.TeamRepo
 	users app.UserRepo
 	tx    app.TxManager
+	clock common.Clock
 }
 
-func NewTeamService(teams app.TeamRepo, users app.UserRepo, tx app.TxManager) *TeamService {
+func NewTeamService(teams app.TeamRepo, users app.UserRepo, tx app.TxManager, clock common.Clock) *TeamService {
 	return &TeamService{
 		teams: teams,
 		users: users,
 		tx:    tx,
+		clock: clock,
 	}
 }
 
 func (s *TeamService) CreateTeam(ctx context.Context, name string, members []entity.User) (entity.Team, []entity.User, error) {
+	ctx, span := tracer.Start(ctx, "TeamService.CreateTeam")
+	defer span.End()
+
 	_, err := s.teams.GetByName(ctx, name)
 	if err == nil {
 		return entity.Team{}, nil, common.ErrTeamExists
@@ -40,10 +48,11 @@ func (s *TeamService) CreateTeam(ctx context.Context, name string, members []ent
 	for i, m := range members {
 		u := m
 		u.TeamName = name
+		u.Skills = entity.NormalizeTags(u.Skills)
 		users[i] = u
 	}
 
-	err = s.tx.InTx(ctx, func(txCtx context.Context) error {
+	err = s.tx.InTx(ctx, app.TxOptions{}, func(txCtx context.Context) error {
 		for _, u := range users {
 			existing, err := s.users.GetByID(txCtx, u.ID)
 			if err != nil && !errors.Is(err, common.ErrNotFound) {
@@ -59,11 +68,16 @@ func (s *TeamService) CreateTeam(ctx context.Context, name string, members []ent
 		}
 
 		if len(users) > 0 {
-			if err := s.users.UpsertMany(txCtx, users); err != nil {
+			if err := s.users.UpsertMany(txCtx, users, s.clock.Now()); err != nil {
 				return err
 			}
 		}
 
+		// The person creating a team administers it, as nobody else holds a role there yet.
+		if actor, ok := actorFromContext(txCtx); ok {
+			return s.teams.SetRole(txCtx, entity.TeamMemberRole{TeamName: name, UserID: actor.UserID, Role: entity.RoleAdmin})
+		}
+
 		return nil
 	})
 
@@ -75,6 +89,9 @@ func (s *TeamService) CreateTeam(ctx context.Context, name string, members []ent
 }
 
 func (s *TeamService) GetTeam(ctx context.Context, name string) (entity.Team, []entity.User, error) {
+	ctx, span := tracer.Start(ctx, "TeamService.GetTeam")
+	defer span.End()
+
 	team, err := s.teams.GetByName(ctx, name)
 	if err != nil {
 		return entity.Team{}, nil, err
@@ -87,3 +104,234 @@ func (s *TeamService) GetTeam(ctx context.Context, name string) (entity.Team, []
 
 	return team, users, nil
 }
+
+func (s *TeamService) SetCodeOwners(ctx context.Context, teamName, content string) (entity.CodeOwners, error) {
+	ctx, span := tracer.Start(ctx, "TeamService.SetCodeOwners")
+	defer span.End()
+
+	rules, err := entity.ParseCodeOwners(content)
+	if err != nil {
+		return entity.CodeOwners{}, err
+	}
+
+	if _, err := s.teams.GetByName(ctx, teamName); err != nil {
+		return entity.CodeOwners{}, err
+	}
+
+	if err := requireTeamRole(ctx, s.teams, teamName, entity.RoleLead); err != nil {
+		return entity.CodeOwners{}, err
+	}
+
+	members, err := s.users.ListByTeamName(ctx, teamName)
+	if err != nil {
+		return entity.CodeOwners{}, err
+	}
+
+	inTeam := make(map[uuid.UUID]struct{}, len(members))
+	for _, u := range members {
+		inTeam[u.ID] = struct{}{}
+	}
+
+	for _, rule := range rules {
+		for _, id := range rule.Owners {
+			if _, ok := inTeam[id]; !ok {
+				return entity.CodeOwners{}, fmt.Errorf("%w: owner %s of %q is not a member of team %q",
+					common.ErrInvalidCodeOwners, id, rule.Pattern, teamName)
+			}
+		}
+	}
+
+	owners := entity.CodeOwners{
+		TeamName: teamName,
+		Rules:    rules,
+	}
+
+	err = s.tx.InTx(ctx, app.TxOptions{}, func(txCtx context.Context) error {
+		return s.teams.ReplaceCodeOwners(txCtx, owners)
+	})
+	if err != nil {
+		return entity.CodeOwners{}, err
+	}
+
+	return owners, nil
+}
+
+func (s *TeamService) GetCodeOwners(ctx context.Context, teamName string) (entity.CodeOwners, error) {
+	ctx, span := tracer.Start(ctx, "TeamService.GetCodeOwners")
+	defer span.End()
+
+	if _, err := s.teams.GetByName(ctx, teamName); err != nil {
+		return entity.CodeOwners{}, err
+	}
+
+	return s.teams.GetCodeOwners(ctx, teamName)
+}
+
+func (s *TeamService) SetRules(ctx context.Context, teamName string, rules []entity.TeamRule) (entity.TeamRules, error) {
+	ctx, span := tracer.Start(ctx, "TeamService.SetRules")
+	defer span.End()
+
+	for i, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return entity.TeamRules{}, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+	}
+
+	if _, err := s.teams.GetByName(ctx, teamName); err != nil {
+		return entity.TeamRules{}, err
+	}
+
+	if err := requireTeamRole(ctx, s.teams, teamName, entity.RoleLead); err != nil {
+		return entity.TeamRules{}, err
+	}
+
+	members, err := s.users.ListByTeamName(ctx, teamName)
+	if err != nil {
+		return entity.TeamRules{}, err
+	}
+
+	inTeam := make(map[uuid.UUID]struct{}, len(members))
+	for _, u := range members {
+		inTeam[u.ID] = struct{}{}
+	}
+
+	for i, rule := range rules {
+		ids := rule.Reviewers
+		if rule.AuthorID != uuid.Nil {
+			ids = append([]uuid.UUID{rule.AuthorID}, ids...)
+		}
+		for _, id := range ids {
+			if _, ok := inTeam[id]; !ok {
+				return entity.TeamRules{}, fmt.Errorf("rule %d: %w: user %s is not a member of team %q",
+					i+1, common.ErrInvalidRules, id, teamName)
+			}
+		}
+	}
+
+	res := entity.TeamRules{
+		TeamName: teamName,
+		Rules:    rules,
+	}
+
+	err = s.tx.InTx(ctx, app.TxOptions{}, func(txCtx context.Context) error {
+		return s.teams.ReplaceRules(txCtx, res)
+	})
+	if err != nil {
+		return entity.TeamRules{}, err
+	}
+
+	return res, nil
+}
+
+func (s *TeamService) GetRules(ctx context.Context, teamName string) (entity.TeamRules, error) {
+	ctx, span := tracer.Start(ctx, "TeamService.GetRules")
+	defer span.End()
+
+	if _, err := s.teams.GetByName(ctx, teamName); err != nil {
+		return entity.TeamRules{}, err
+	}
+
+	return s.teams.GetRules(ctx, teamName)
+}
+
+func (s *TeamService) GetSettings(ctx context.Context, teamName string) (entity.TeamSettings, error) {
+	ctx, span := tracer.Start(ctx, "TeamService.GetSettings")
+	defer span.End()
+
+	if _, err := s.teams.GetByName(ctx, teamName); err != nil {
+		return entity.TeamSettings{}, err
+	}
+
+	return s.teams.GetSettings(ctx, teamName)
+}
+
+func (s *TeamService) UpdateSettings(ctx context.Context, teamName string, patch entity.TeamSettingsPatch) (entity.TeamSettings, error) {
+	ctx, span := tracer.Start(ctx, "TeamService.UpdateSettings")
+	defer span.End()
+
+	var result entity.TeamSettings
+
+	err := s.tx.InTx(ctx, app.TxOptions{}, func(txCtx context.Context) error {
+		if _, err := s.teams.GetByName(txCtx, teamName); err != nil {
+			return err
+		}
+
+		if err := requireTeamRole(txCtx, s.teams, teamName, entity.RoleLead); err != nil {
+			return err
+		}
+
+		current, err := s.teams.GetSettings(txCtx, teamName)
+		if err != nil {
+			return err
+		}
+
+		result = current.Apply(patch)
+		if err := result.Validate(); err != nil {
+			return err
+		}
+
+		if result.EscalateAfter > 0 && result.EscalationPolicy == entity.EscalationAddLead {
+			leads, err := teamLeads(txCtx, s.teams, teamName)
+			if err != nil {
+				return err
+			}
+			if len(leads) == 0 {
+				return fmt.Errorf("%w: escalation policy %q requires a team lead", common.ErrInvalidTeamSettings, result.EscalationPolicy)
+			}
+		}
+
+		return s.teams.UpsertSettings(txCtx, result)
+	})
+	if err != nil {
+		return entity.TeamSettings{}, err
+	}
+
+	return result, nil
+}
+
+// SetRole grants a role in the team to one of its members; only team admins may do it.
+func (s *TeamService) SetRole(ctx context.Context, role entity.TeamMemberRole) (entity.TeamMemberRole, error) {
+	ctx, span := tracer.Start(ctx, "TeamService.SetRole")
+	defer span.End()
+
+	if !role.Role.IsValid() {
+		return entity.TeamMemberRole{}, common.ErrInvalidRole
+	}
+
+	err := s.tx.InTx(ctx, app.TxOptions{}, func(txCtx context.Context) error {
+		if _, err := s.teams.GetByName(txCtx, role.TeamName); err != nil {
+			return err
+		}
+
+		if err := requireTeamRole(txCtx, s.teams, role.TeamName, entity.RoleAdmin); err != nil {
+			return err
+		}
+
+		user, err := s.users.GetByID(txCtx, role.UserID)
+		if err != nil {
+			return err
+		}
+		if user.TeamName != role.TeamName {
+			return fmt.Errorf("%w: user %s is not a member of team %q", common.ErrInvalidRole, role.UserID, role.TeamName)
+		}
+
+		return s.teams.SetRole(txCtx, role)
+	})
+	if err != nil {
+		return entity.TeamMemberRole{}, err
+	}
+
+	return role, nil
+}
+
+// ListRoles returns granted lead and admin roles; everyone else in the team is a member.
+func (s *TeamService) ListRoles(ctx context.Context, teamName string) ([]entity.TeamMemberRole, error) {
+	ctx, span := tracer.Start(ctx, "TeamService.ListRoles")
+	defer span.End()
+
+	if _, err := s.teams.GetByName(ctx, teamName); err != nil {
+		return nil, err
+	}
+
+	return s.teams.ListRoles(ctx, teamName)
+}
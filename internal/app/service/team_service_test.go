@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -17,7 +18,7 @@ func TestTeamService_CreateTeam_Success(t *testing.T) {
 	teamRepo := newFakeTeamRepo()
 	userRepo := newFakeUserRepo()
 
-	svc := NewTeamService(teamRepo, userRepo, fakeTx{})
+	svc := NewTeamService(teamRepo, userRepo, fakeTx{}, fakeClock{})
 
 	members := []entity.User{
 		{ID: uuid.New(), Name: "Alice", IsActive: true},
@@ -66,7 +67,7 @@ func TestTeamService_CreateTeam_TeamExists(t *testing.T) {
 	existingName := teamName
 	teamRepo.teams[existingName] = entity.Team{Name: existingName}
 
-	svc := NewTeamService(teamRepo, userRepo, fakeTx{})
+	svc := NewTeamService(teamRepo, userRepo, fakeTx{}, fakeClock{})
 
 	_, _, err := svc.CreateTeam(ctx, existingName, []entity.User{
 		{ID: uuid.New(), Name: "Alice", IsActive: true},
@@ -90,7 +91,7 @@ func TestTeamService_CreateTeam_UserInAnotherTeam(t *testing.T) {
 		IsActive: true,
 	}
 
-	svc := NewTeamService(teamRepo, userRepo, fakeTx{})
+	svc := NewTeamService(teamRepo, userRepo, fakeTx{}, fakeClock{})
 
 	members := []entity.User{
 		{ID: existingID, Name: "Existing", IsActive: true},
@@ -122,7 +123,7 @@ func TestTeamService_GetTeam_Success(t *testing.T) {
 	userRepo.users[u2.ID] = u2
 	userRepo.users[uOther.ID] = uOther
 
-	svc := NewTeamService(teamRepo, userRepo, fakeTx{})
+	svc := NewTeamService(teamRepo, userRepo, fakeTx{}, fakeClock{})
 
 	team, members, err := svc.GetTeam(ctx, teamName)
 	if err != nil {
@@ -150,10 +151,213 @@ func TestTeamService_GetTeam_NotFound(t *testing.T) {
 	teamRepo := newFakeTeamRepo()
 	userRepo := newFakeUserRepo()
 
-	svc := NewTeamService(teamRepo, userRepo, fakeTx{})
+	svc := NewTeamService(teamRepo, userRepo, fakeTx{}, fakeClock{})
 
 	_, _, err := svc.GetTeam(ctx, "unknown")
 	if !errors.Is(err, common.ErrNotFound) {
 		t.Fatalf("expected ErrNotFound, got %v", err)
 	}
 }
+
+func TestTeamService_SetCodeOwners_Success(t *testing.T) {
+	ctx := context.Background()
+
+	teamRepo := newFakeTeamRepo()
+	userRepo := newFakeUserRepo()
+
+	teamRepo.teams[teamName] = entity.Team{Name: teamName}
+
+	alice := entity.User{ID: uuid.New(), TeamName: teamName, Name: "Alice", IsActive: true}
+	bob := entity.User{ID: uuid.New(), TeamName: teamName, Name: "Bob", IsActive: true}
+	userRepo.users[alice.ID] = alice
+	userRepo.users[bob.ID] = bob
+
+	svc := NewTeamService(teamRepo, userRepo, fakeTx{}, fakeClock{})
+
+	content := "# backend owners\n" +
+		"*        @" + alice.ID.String() + "\n" +
+		"\n" +
+		"/migrations/ " + bob.ID.String() + " @" + alice.ID.String() + " # db\n"
+
+	owners, err := svc.SetCodeOwners(ctx, teamName, content)
+	if err != nil {
+		t.Fatalf("SetCodeOwners returned error: %v", err)
+	}
+
+	if len(owners.Rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(owners.Rules))
+	}
+	if owners.Rules[1].Pattern != "/migrations/" {
+		t.Fatalf("unexpected pattern %q", owners.Rules[1].Pattern)
+	}
+	if len(owners.Rules[1].Owners) != 2 || owners.Rules[1].Owners[0] != bob.ID {
+		t.Fatalf("unexpected owners %v", owners.Rules[1].Owners)
+	}
+
+	stored, ok := teamRepo.codeOwners[teamName]
+	if !ok || len(stored.Rules) != 2 {
+		t.Fatalf("code owners not stored in repo")
+	}
+}
+
+func TestTeamService_SetCodeOwners_Invalid(t *testing.T) {
+	ctx := context.Background()
+
+	teamRepo := newFakeTeamRepo()
+	userRepo := newFakeUserRepo()
+
+	teamRepo.teams[teamName] = entity.Team{Name: teamName}
+
+	member := entity.User{ID: uuid.New(), TeamName: teamName, Name: "Alice", IsActive: true}
+	stranger := entity.User{ID: uuid.New(), TeamName: "other", Name: "Eve", IsActive: true}
+	userRepo.users[member.ID] = member
+	userRepo.users[stranger.ID] = stranger
+
+	svc := NewTeamService(teamRepo, userRepo, fakeTx{}, fakeClock{})
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "owner is not a uuid", content: "*.go @alice"},
+		{name: "owner from another team", content: "*.go @" + stranger.ID.String()},
+		{name: "negation", content: "!*.go @" + member.ID.String()},
+		{name: "bad glob", content: "src/[a @" + member.ID.String()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.SetCodeOwners(ctx, teamName, tt.content)
+			if !errors.Is(err, common.ErrInvalidCodeOwners) {
+				t.Fatalf("expected ErrInvalidCodeOwners, got %v", err)
+			}
+		})
+	}
+
+	if _, ok := teamRepo.codeOwners[teamName]; ok {
+		t.Fatalf("invalid code owners must not be stored")
+	}
+}
+
+func TestTeamService_UpdateSettings_AppliesPatch(t *testing.T) {
+	ctx := context.Background()
+
+	teamRepo := newFakeTeamRepo()
+	teamRepo.teams[teamName] = entity.Team{Name: teamName}
+
+	svc := NewTeamService(teamRepo, newFakeUserRepo(), fakeTx{}, fakeClock{})
+
+	enabled := true
+	settings, err := svc.UpdateSettings(ctx, teamName, entity.TeamSettingsPatch{ReassignOnLeave: &enabled})
+	if err != nil {
+		t.Fatalf("UpdateSettings returned error: %v", err)
+	}
+	if !settings.ReassignOnLeave || !teamRepo.settings[teamName].ReassignOnLeave {
+		t.Fatalf("reassign_on_leave must be enabled")
+	}
+
+	settings, err = svc.UpdateSettings(ctx, teamName, entity.TeamSettingsPatch{})
+	if err != nil {
+		t.Fatalf("UpdateSettings returned error: %v", err)
+	}
+	if !settings.ReassignOnLeave {
+		t.Fatalf("empty patch must keep current settings")
+	}
+
+	_, err = svc.UpdateSettings(ctx, "unknown", entity.TeamSettingsPatch{ReassignOnLeave: &enabled})
+	if !errors.Is(err, common.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestTeamService_UpdateSettings_RejectsUnknownCapacityPolicy(t *testing.T) {
+	teamRepo := newFakeTeamRepo()
+	teamRepo.teams[teamName] = entity.Team{Name: teamName}
+
+	svc := NewTeamService(teamRepo, newFakeUserRepo(), fakeTx{}, fakeClock{})
+
+	policy := entity.CapacityPolicy("round_robin")
+	_, err := svc.UpdateSettings(context.Background(), teamName, entity.TeamSettingsPatch{CapacityPolicy: &policy})
+	if !errors.Is(err, common.ErrInvalidTeamSettings) {
+		t.Fatalf("expected ErrInvalidTeamSettings, got %v", err)
+	}
+	if _, stored := teamRepo.settings[teamName]; stored {
+		t.Fatalf("invalid settings must not be stored")
+	}
+}
+
+func TestTeamService_SetRules_Validation(t *testing.T) {
+	ctx := context.Background()
+
+	teamRepo := newFakeTeamRepo()
+	userRepo := newFakeUserRepo()
+	teamRepo.teams[teamName] = entity.Team{Name: teamName}
+
+	author := uuid.New()
+	mentor := uuid.New()
+	userRepo.users[author] = entity.User{ID: author, TeamName: teamName, Name: "Author", IsActive: true}
+	userRepo.users[mentor] = entity.User{ID: mentor, TeamName: teamName, Name: "Mentor", IsActive: true}
+
+	svc := NewTeamService(teamRepo, userRepo, fakeTx{}, fakeClock{})
+
+	tests := []struct {
+		name  string
+		rules []entity.TeamRule
+	}{
+		{name: "unknown kind", rules: []entity.TeamRule{{Kind: "sometimes"}}},
+		{name: "never without author", rules: []entity.TeamRule{{Kind: entity.RuleNever, Reviewers: []uuid.UUID{mentor}}}},
+		{name: "require one of without reviewers", rules: []entity.TeamRule{{Kind: entity.RuleRequireOneOf, AuthorID: author}}},
+		{name: "reviewer outside team", rules: []entity.TeamRule{{Kind: entity.RuleNever, AuthorID: author, Reviewers: []uuid.UUID{uuid.New()}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.SetRules(ctx, teamName, tt.rules)
+			if !errors.Is(err, common.ErrInvalidRules) {
+				t.Fatalf("expected ErrInvalidRules, got %v", err)
+			}
+		})
+	}
+
+	rules, err := svc.SetRules(ctx, teamName, []entity.TeamRule{
+		{Kind: entity.RuleNever, AuthorID: author, Reviewers: []uuid.UUID{mentor}},
+		{Kind: entity.RuleRequireSenior},
+	})
+	if err != nil {
+		t.Fatalf("SetRules returned error: %v", err)
+	}
+	if len(rules.Rules) != 2 || len(teamRepo.rules[teamName].Rules) != 2 {
+		t.Fatalf("expected 2 stored rules, got %v", teamRepo.rules[teamName].Rules)
+	}
+}
+
+func TestTeamService_UpdateSettings_EscalationLead(t *testing.T) {
+	ctx := context.Background()
+
+	teamRepo := newFakeTeamRepo()
+	userRepo := newFakeUserRepo()
+	teamRepo.teams[teamName] = entity.Team{Name: teamName}
+
+	leadID := uuid.New()
+	userRepo.users[leadID] = entity.User{ID: leadID, TeamName: teamName, Name: "Lead", IsActive: true}
+
+	svc := NewTeamService(teamRepo, userRepo, fakeTx{}, fakeClock{})
+
+	after := time.Hour
+	policy := entity.EscalationAddLead
+
+	_, err := svc.UpdateSettings(ctx, teamName, entity.TeamSettingsPatch{EscalateAfter: &after, EscalationPolicy: &policy})
+	if !errors.Is(err, common.ErrInvalidTeamSettings) {
+		t.Fatalf("add_lead without a lead: expected ErrInvalidTeamSettings, got %v", err)
+	}
+
+	teamRepo.roles[teamName] = map[uuid.UUID]entity.TeamRole{leadID: entity.RoleLead}
+
+	settings, err := svc.UpdateSettings(ctx, teamName, entity.TeamSettingsPatch{EscalateAfter: &after, EscalationPolicy: &policy})
+	if err != nil {
+		t.Fatalf("add_lead with a lead: %v", err)
+	}
+	if settings.EscalationPolicy != entity.EscalationAddLead {
+		t.Fatalf("unexpected settings %+v", settings)
+	}
+}
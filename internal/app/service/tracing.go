@@ -0,0 +1,6 @@
+package service
+
+import "go.opentelemetry.io/otel"
+
+// tracer resolves the global provider lazily, so spans go to whatever main or tests install.
+var tracer = otel.Tracer("github.com/Desnn1ch/pr-reviewer-service/internal/app/service")
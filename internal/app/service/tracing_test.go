@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+func TestPRService_Create_Spans(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	otel.SetTracerProvider(tp)
+
+	userRepo := newFakeUserRepo()
+	authorID, r1 := uuid.New(), uuid.New()
+	userRepo.users[authorID] = entity.User{ID: authorID, TeamName: teamName, Name: "Author", IsActive: true}
+	userRepo.users[r1] = entity.User{ID: r1, TeamName: teamName, Name: "R1", IsActive: true}
+
+	svc := NewPRService(newFakePRRepo(), userRepo, newFakeTeamRepo(), fakeTx{}, common.StandardClock{}, &fakeMetrics{}, discardLogger)
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "request")
+	if _, _, err := svc.Create(ctx, entity.PR{ID: uuid.New(), Title: "Add search", AuthorID: authorID}); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	parent.End()
+
+	byName := make(map[string]tracetest.SpanStub)
+	for _, s := range exp.GetSpans() {
+		byName[s.Name] = s
+	}
+
+	create, ok := byName["PRService.Create"]
+	if !ok {
+		t.Fatalf("no PRService.Create span, got %v", byName)
+	}
+	if create.Parent.SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("PRService.Create must be a child of the caller span")
+	}
+
+	sel, ok := byName["PRService.selectReviewers"]
+	if !ok {
+		t.Fatalf("no PRService.selectReviewers span")
+	}
+	if sel.Parent.SpanID() != create.SpanContext.SpanID() {
+		t.Errorf("selectReviewers must be a child of PRService.Create")
+	}
+}
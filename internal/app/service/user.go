@@ -6,32 +6,44 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/Desnn1ch/pr-reviewer-service/internal/app"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
 	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
 )
 
 type UserService struct {
 	users app.UserRepo
 	prs   app.PRRepo
+	teams app.TeamRepo
+	clock common.Clock
 }
 
-func NewUserService(users app.UserRepo, prs app.PRRepo) *UserService {
+func NewUserService(users app.UserRepo, prs app.PRRepo, teams app.TeamRepo, clock common.Clock) *UserService {
 	return &UserService{
 		users: users,
 		prs:   prs,
+		teams: teams,
+		clock: clock,
 	}
 }
 
 func (s *UserService) SetActive(ctx context.Context, userID uuid.UUID, isActive bool) (entity.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.SetActive")
+	defer span.End()
+
 	user, err := s.users.GetByID(ctx, userID)
 	if err != nil {
 		return entity.User{}, err
 	}
 
+	if err := requireSelfOrTeamRole(ctx, s.teams, user.TeamName, entity.RoleLead, userID); err != nil {
+		return entity.User{}, err
+	}
+
 	if user.IsActive == isActive {
 		return user, nil
 	}
 
-	if err := s.users.SetActive(ctx, userID, isActive); err != nil {
+	if err := s.users.SetActive(ctx, userID, isActive, s.clock.Now()); err != nil {
 		return entity.User{}, err
 	}
 
@@ -40,6 +52,9 @@ func (s *UserService) SetActive(ctx context.Context, userID uuid.UUID, isActive
 }
 
 func (s *UserService) GetReviews(ctx context.Context, userID uuid.UUID) ([]entity.PR, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetReviews")
+	defer span.End()
+
 	_, err := s.users.GetByID(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -52,3 +67,165 @@ func (s *UserService) GetReviews(ctx context.Context, userID uuid.UUID) ([]entit
 
 	return prs, nil
 }
+
+func (s *UserService) SetSkills(ctx context.Context, userID uuid.UUID, skills []string) (entity.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.SetSkills")
+	defer span.End()
+
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	if err := requireSelfOrTeamRole(ctx, s.teams, user.TeamName, entity.RoleLead, userID); err != nil {
+		return entity.User{}, err
+	}
+
+	skills = entity.NormalizeTags(skills)
+
+	if err := s.users.SetSkills(ctx, userID, skills); err != nil {
+		return entity.User{}, err
+	}
+
+	user.Skills = skills
+	return user, nil
+}
+
+func (s *UserService) SetWorkingHours(ctx context.Context, userID uuid.UUID, wh *entity.WorkingHours) (entity.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.SetWorkingHours")
+	defer span.End()
+
+	if wh != nil {
+		if err := wh.Validate(); err != nil {
+			return entity.User{}, err
+		}
+	}
+
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	if err := requireSelfOrTeamRole(ctx, s.teams, user.TeamName, entity.RoleLead, userID); err != nil {
+		return entity.User{}, err
+	}
+
+	if err := s.users.SetWorkingHours(ctx, userID, wh); err != nil {
+		return entity.User{}, err
+	}
+
+	user.WorkingHours = wh
+	return user, nil
+}
+
+func (s *UserService) SetMaxOpenReviews(ctx context.Context, userID uuid.UUID, limit *int) (entity.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.SetMaxOpenReviews")
+	defer span.End()
+
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	if err := requireSelfOrTeamRole(ctx, s.teams, user.TeamName, entity.RoleLead, userID); err != nil {
+		return entity.User{}, err
+	}
+
+	if err := s.users.SetMaxOpenReviews(ctx, userID, limit); err != nil {
+		return entity.User{}, err
+	}
+
+	user.MaxOpenReviews = limit
+	return user, nil
+}
+
+func (s *UserService) AddUnavailability(ctx context.Context, u entity.Unavailability) (entity.Unavailability, error) {
+	ctx, span := tracer.Start(ctx, "UserService.AddUnavailability")
+	defer span.End()
+
+	if !u.IsValid() {
+		return entity.Unavailability{}, common.ErrInvalidInterval
+	}
+
+	if err := s.authorizeAvailability(ctx, u.UserID); err != nil {
+		return entity.Unavailability{}, err
+	}
+
+	if u.ID == uuid.Nil {
+		u.ID = uuid.New()
+	}
+	u.HandledAt = nil
+
+	if err := s.users.CreateUnavailability(ctx, u); err != nil {
+		return entity.Unavailability{}, err
+	}
+
+	return u, nil
+}
+
+func (s *UserService) UpdateUnavailability(ctx context.Context, u entity.Unavailability) (entity.Unavailability, error) {
+	ctx, span := tracer.Start(ctx, "UserService.UpdateUnavailability")
+	defer span.End()
+
+	if !u.IsValid() {
+		return entity.Unavailability{}, common.ErrInvalidInterval
+	}
+
+	existing, err := s.users.GetUnavailability(ctx, u.ID)
+	if err != nil {
+		return entity.Unavailability{}, err
+	}
+
+	if err := s.authorizeAvailability(ctx, existing.UserID); err != nil {
+		return entity.Unavailability{}, err
+	}
+
+	u.UserID = existing.UserID
+	u.HandledAt = existing.HandledAt
+	if !u.StartsAt.Equal(existing.StartsAt) {
+		u.HandledAt = nil
+	}
+
+	if err := s.users.UpdateUnavailability(ctx, u); err != nil {
+		return entity.Unavailability{}, err
+	}
+
+	return u, nil
+}
+
+func (s *UserService) DeleteUnavailability(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "UserService.DeleteUnavailability")
+	defer span.End()
+
+	existing, err := s.users.GetUnavailability(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.authorizeAvailability(ctx, existing.UserID); err != nil {
+		return err
+	}
+
+	return s.users.DeleteUnavailability(ctx, id)
+}
+
+func (s *UserService) ListUnavailability(ctx context.Context, userID uuid.UUID) ([]entity.Unavailability, error) {
+	ctx, span := tracer.Start(ctx, "UserService.ListUnavailability")
+	defer span.End()
+
+	if _, err := s.users.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return s.users.ListUnavailability(ctx, userID)
+}
+
+// authorizeAvailability lets members change only their own availability and leads that of their team.
+func (s *UserService) authorizeAvailability(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return requireSelfOrTeamRole(ctx, s.teams, user.TeamName, entity.RoleLead, userID)
+}
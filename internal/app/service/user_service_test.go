@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -24,7 +25,7 @@ func TestUserService_SetActive_ChangeState(t *testing.T) {
 		IsActive: true,
 	}
 
-	svc := NewUserService(userRepo, prRepo)
+	svc := NewUserService(userRepo, prRepo, newFakeTeamRepo(), fakeClock{})
 
 	updated, err := svc.SetActive(ctx, id, false)
 	if err != nil {
@@ -57,7 +58,7 @@ func TestUserService_SetActive_NoChange(t *testing.T) {
 		IsActive: true,
 	}
 
-	svc := NewUserService(userRepo, prRepo)
+	svc := NewUserService(userRepo, prRepo, newFakeTeamRepo(), fakeClock{})
 
 	updated, err := svc.SetActive(ctx, id, true)
 	if err != nil {
@@ -84,7 +85,7 @@ func TestUserService_SetActive_GetByIDError(t *testing.T) {
 
 	id := uuid.New()
 
-	svc := NewUserService(userRepo, prRepo)
+	svc := NewUserService(userRepo, prRepo, newFakeTeamRepo(), fakeClock{})
 
 	_, err := svc.SetActive(ctx, id, false)
 	if !errors.Is(err, someErr) {
@@ -108,7 +109,7 @@ func TestUserService_SetActive_SetActiveError(t *testing.T) {
 	setErr := errors.New("update failed")
 	userRepo.setErr = setErr
 
-	svc := NewUserService(userRepo, prRepo)
+	svc := NewUserService(userRepo, prRepo, newFakeTeamRepo(), fakeClock{})
 
 	_, err := svc.SetActive(ctx, id, false)
 	if !errors.Is(err, setErr) {
@@ -134,7 +135,7 @@ func TestUserService_GetReviews_Success(t *testing.T) {
 	prRepo.prs[pr1.ID] = pr1
 	prRepo.prs[pr2.ID] = pr2
 
-	svc := NewUserService(userRepo, prRepo)
+	svc := NewUserService(userRepo, prRepo, newFakeTeamRepo(), fakeClock{})
 
 	prs, err := svc.GetReviews(ctx, id)
 	if err != nil {
@@ -152,7 +153,7 @@ func TestUserService_GetReviews_UserNotFound(t *testing.T) {
 	userRepo := newFakeUserRepo()
 	prRepo := newFakePRRepo()
 
-	svc := NewUserService(userRepo, prRepo)
+	svc := NewUserService(userRepo, prRepo, newFakeTeamRepo(), fakeClock{})
 
 	_, err := svc.GetReviews(ctx, uuid.New())
 	if !errors.Is(err, common.ErrNotFound) {
@@ -176,10 +177,137 @@ func TestUserService_GetReviews_ListError(t *testing.T) {
 	listErr := errors.New("list failed")
 	prRepo.listErr = listErr
 
-	svc := NewUserService(userRepo, prRepo)
+	svc := NewUserService(userRepo, prRepo, newFakeTeamRepo(), fakeClock{})
 
 	_, err := svc.GetReviews(ctx, id)
 	if !errors.Is(err, listErr) {
 		t.Fatalf("expected listErr (%v), got %v", listErr, err)
 	}
 }
+
+func TestUserService_SetSkills_Normalizes(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newFakeUserRepo()
+	prRepo := newFakePRRepo()
+
+	id := uuid.New()
+	userRepo.users[id] = entity.User{ID: id, Name: "Alice", IsActive: true}
+
+	svc := NewUserService(userRepo, prRepo, newFakeTeamRepo(), fakeClock{})
+
+	updated, err := svc.SetSkills(ctx, id, []string{"Go", " sql ", "", "go"})
+	if err != nil {
+		t.Fatalf("SetSkills returned error: %v", err)
+	}
+
+	want := []string{"go", "sql"}
+	if len(updated.Skills) != len(want) {
+		t.Fatalf("expected skills %v, got %v", want, updated.Skills)
+	}
+	for i := range want {
+		if updated.Skills[i] != want[i] || userRepo.users[id].Skills[i] != want[i] {
+			t.Fatalf("expected skills %v, got %v", want, updated.Skills)
+		}
+	}
+}
+
+func TestUserService_SetSkills_NotFound(t *testing.T) {
+	svc := NewUserService(newFakeUserRepo(), newFakePRRepo(), newFakeTeamRepo(), fakeClock{})
+
+	_, err := svc.SetSkills(context.Background(), uuid.New(), []string{"go"})
+	if !errors.Is(err, common.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestUserService_AddUnavailability_InvalidInterval(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newFakeUserRepo()
+
+	id := uuid.New()
+	userRepo.users[id] = entity.User{ID: id, Name: "Alice", IsActive: true}
+
+	svc := NewUserService(userRepo, newFakePRRepo(), newFakeTeamRepo(), fakeClock{})
+
+	start := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := svc.AddUnavailability(ctx, entity.Unavailability{UserID: id, StartsAt: start, EndsAt: start})
+	if !errors.Is(err, common.ErrInvalidInterval) {
+		t.Fatalf("expected ErrInvalidInterval, got %v", err)
+	}
+
+	if len(userRepo.leaves) != 0 {
+		t.Fatalf("invalid window must not be stored")
+	}
+}
+
+func TestUserService_UpdateUnavailability_ResetsHandledOnNewStart(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := newFakeUserRepo()
+
+	userID := uuid.New()
+	userRepo.users[userID] = entity.User{ID: userID, Name: "Alice", IsActive: true}
+
+	svc := NewUserService(userRepo, newFakePRRepo(), newFakeTeamRepo(), fakeClock{})
+
+	start := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	created, err := svc.AddUnavailability(ctx, entity.Unavailability{UserID: userID, StartsAt: start, EndsAt: start.Add(72 * time.Hour), Reason: "vacation"})
+	if err != nil {
+		t.Fatalf("AddUnavailability returned error: %v", err)
+	}
+
+	handled := start.Add(time.Minute)
+	if err := userRepo.MarkUnavailabilityHandled(ctx, created.ID, handled); err != nil {
+		t.Fatalf("MarkUnavailabilityHandled returned error: %v", err)
+	}
+
+	updated, err := svc.UpdateUnavailability(ctx, entity.Unavailability{
+		ID:       created.ID,
+		StartsAt: start,
+		EndsAt:   start.Add(96 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("UpdateUnavailability returned error: %v", err)
+	}
+	if updated.HandledAt == nil || updated.UserID != userID {
+		t.Fatalf("extending a window must keep owner and handled mark, got %+v", updated)
+	}
+
+	moved, err := svc.UpdateUnavailability(ctx, entity.Unavailability{
+		ID:       created.ID,
+		StartsAt: start.Add(24 * time.Hour),
+		EndsAt:   start.Add(96 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("UpdateUnavailability returned error: %v", err)
+	}
+	if moved.HandledAt != nil {
+		t.Fatalf("moving the start must reset handled mark")
+	}
+}
+
+func TestUserService_SetWorkingHours_UnknownTimezone(t *testing.T) {
+	userRepo := newFakeUserRepo()
+
+	id := uuid.New()
+	userRepo.users[id] = entity.User{ID: id, Name: "Alice", IsActive: true}
+
+	svc := NewUserService(userRepo, newFakePRRepo(), newFakeTeamRepo(), fakeClock{})
+
+	_, err := svc.SetWorkingHours(context.Background(), id, &entity.WorkingHours{
+		Timezone:    "Mars/Olympus",
+		StartMinute: 9 * 60,
+		EndMinute:   18 * 60,
+		Days:        entity.DefaultWorkDays(),
+	})
+	if !errors.Is(err, common.ErrInvalidWorkingHours) {
+		t.Fatalf("expected ErrInvalidWorkingHours, got %v", err)
+	}
+
+	if userRepo.users[id].WorkingHours != nil {
+		t.Fatalf("invalid profile must not be stored")
+	}
+}
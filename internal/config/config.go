@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net/netip"
 	"os"
 	"time"
 
@@ -33,11 +34,38 @@ func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
+type Prefix struct {
+	netip.Prefix
+}
+
+// UnmarshalYAML accepts a CIDR such as "10.0.0.0/8" or a single address.
+func (p *Prefix) UnmarshalYAML(value *yaml.Node) error {
+	var s string
+	if err := value.Decode(&s); err != nil {
+		return err
+	}
+	if addr, err := netip.ParseAddr(s); err == nil {
+		p.Prefix = netip.PrefixFrom(addr, addr.BitLen())
+		return nil
+	}
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		return err
+	}
+	p.Prefix = prefix.Masked()
+	return nil
+}
+
 type Server struct {
 	Address      string   `yaml:"address"`
 	ReadTimeout  Duration `yaml:"readTimeout"`
 	WriteTimeout Duration `yaml:"writeTimeout"`
 	IdleTimeout  Duration `yaml:"idleTimeout"`
+	// DrainDelay is how long /readyz fails before shutdown starts, so load balancers can react.
+	DrainDelay Duration `yaml:"drainDelay"`
+	// TrustedProxies are the load balancers allowed to pass the client address in
+	// X-Forwarded-For or X-Real-IP; the headers of anyone else are ignored.
+	TrustedProxies []Prefix `yaml:"trustedProxies"`
 }
 
 type DBPool struct {
@@ -54,6 +82,8 @@ type Database struct {
 	Name     string `yaml:"name"`
 	SSLMode  string `yaml:"sslmode"`
 	Pool     DBPool `yaml:"pool"`
+	// TxMaxAttempts bounds retries of transactions aborted by serialization failures or deadlocks.
+	TxMaxAttempts int `yaml:"txMaxAttempts"`
 }
 
 func (d Database) DSN() string {
@@ -68,9 +98,97 @@ func (d Database) DSN() string {
 	)
 }
 
+type Jobs struct {
+	LeaveReassignInterval Duration `yaml:"leaveReassignInterval"`
+	EscalationInterval    Duration `yaml:"escalationInterval"`
+	DigestInterval        Duration `yaml:"digestInterval"`
+	MetricsInterval       Duration `yaml:"metricsInterval"`
+	IdempotencyCleanup    Duration `yaml:"idempotencyCleanupInterval"`
+}
+
+type SMTP struct {
+	Host     string   `yaml:"host"`
+	Port     int      `yaml:"port"`
+	Username string   `yaml:"username"`
+	Password string   `yaml:"password"`
+	From     string   `yaml:"from"`
+	Timeout  Duration `yaml:"timeout"`
+}
+
+type Webhook struct {
+	URL     string   `yaml:"url"`
+	Timeout Duration `yaml:"timeout"`
+}
+
+// Notifications channels are enabled by setting smtp.host or webhook.url.
+type Notifications struct {
+	SMTP    SMTP    `yaml:"smtp"`
+	Webhook Webhook `yaml:"webhook"`
+}
+
+// Log level is debug, info, warn or error; format is json or text.
+type Log struct {
+	Level  string `yaml:"level"`
+	Format string `yaml:"format"`
+}
+
+// Tracing exporter is none, stdout or otlp (OTLP over HTTP).
+type Tracing struct {
+	Exporter    string  `yaml:"exporter"`
+	Endpoint    string  `yaml:"endpoint"`
+	Insecure    bool    `yaml:"insecure"`
+	SampleRatio float64 `yaml:"sampleRatio"`
+	ServiceName string  `yaml:"serviceName"`
+}
+
+// JWT accepts tokens of people signed by keys from jwksUrl or jwksFile; it is off when both are empty.
+type JWT struct {
+	JWKSURL      string `yaml:"jwksUrl"`
+	JWKSFile     string `yaml:"jwksFile"`
+	Issuer       string `yaml:"issuer"`
+	Audience     string `yaml:"audience"`
+	SubjectClaim string `yaml:"subjectClaim"`
+}
+
+// Auth requires API keys on every API route when enabled. AdminKey is a bootstrap secret with
+// the admin scope for creating the first keys; the ADMIN_API_KEY variable overrides it.
+type Auth struct {
+	Enabled  bool   `yaml:"enabled"`
+	AdminKey string `yaml:"adminKey"`
+	JWT      JWT    `yaml:"jwt"`
+}
+
+type Limit struct {
+	RPS   float64 `yaml:"rps"`
+	Burst int     `yaml:"burst"`
+}
+
+// RateLimit applies token buckets per IP address before authentication and per caller after it;
+// routes overrides of the per-caller limit are keyed by "METHOD /path".
+type RateLimit struct {
+	Enabled bool             `yaml:"enabled"`
+	PerIP   Limit            `yaml:"perIp"`
+	RPS     float64          `yaml:"rps"`
+	Burst   int              `yaml:"burst"`
+	Routes  map[string]Limit `yaml:"routes"`
+}
+
+// Idempotency stores responses of POST requests sent with an Idempotency-Key header for TTL.
+type Idempotency struct {
+	Enabled bool     `yaml:"enabled"`
+	TTL     Duration `yaml:"ttl"`
+}
+
 type Config struct {
-	Server   Server   `yaml:"server"`
-	Database Database `yaml:"database"`
+	Server        Server        `yaml:"server"`
+	Database      Database      `yaml:"database"`
+	Jobs          Jobs          `yaml:"jobs"`
+	Notifications Notifications `yaml:"notifications"`
+	Tracing       Tracing       `yaml:"tracing"`
+	Log           Log           `yaml:"log"`
+	Auth          Auth          `yaml:"auth"`
+	RateLimit     RateLimit     `yaml:"rateLimit"`
+	Idempotency   Idempotency   `yaml:"idempotency"`
 }
 
 func Load(path string) (Config, error) {
@@ -3,11 +3,24 @@ package common
 import "errors"
 
 var (
-	ErrTeamExists        = errors.New("team already exists")
-	ErrPRExists          = errors.New("pr already exists")
-	ErrPRMerged          = errors.New("pr merged")
-	ErrNotAssigned       = errors.New("reviewer not assigned")
-	ErrNoCandidate       = errors.New("no candidate available")
-	ErrNotFound          = errors.New("not found")
-	ErrUserInAnotherTeam = errors.New("user already belongs to another team")
+	ErrTeamExists          = errors.New("team already exists")
+	ErrPRExists            = errors.New("pr already exists")
+	ErrPRMerged            = errors.New("pr merged")
+	ErrNotAssigned         = errors.New("reviewer not assigned")
+	ErrNoCandidate         = errors.New("no candidate available")
+	ErrNotFound            = errors.New("not found")
+	ErrUserInAnotherTeam   = errors.New("user already belongs to another team")
+	ErrInvalidCodeOwners   = errors.New("invalid codeowners")
+	ErrInvalidInterval     = errors.New("invalid time interval")
+	ErrInvalidWorkingHours = errors.New("invalid working hours")
+	ErrInvalidTeamSettings = errors.New("invalid team settings")
+	ErrInvalidRules        = errors.New("invalid team rules")
+	ErrRulesUnsatisfied    = errors.New("team rules cannot be satisfied")
+	ErrInvalidAPIKey       = errors.New("invalid api key")
+	ErrUnauthorized        = errors.New("unauthorized")
+	ErrForbidden           = errors.New("forbidden")
+	ErrInvalidRole         = errors.New("invalid team role")
+	ErrIdempotencyMismatch = errors.New("idempotency key reused with a different request")
+	ErrIdempotencyPending  = errors.New("request with this idempotency key is in progress")
+	ErrConflict            = errors.New("resource was modified concurrently")
 )
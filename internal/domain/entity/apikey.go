@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Scope string
+
+const (
+	ScopeTeamsRead  Scope = "teams:read"
+	ScopeTeamsWrite Scope = "teams:write"
+	ScopeUsersRead  Scope = "users:read"
+	ScopeUsersWrite Scope = "users:write"
+	ScopePRsRead    Scope = "prs:read"
+	ScopePRsWrite   Scope = "prs:write"
+	ScopeStatsRead  Scope = "stats:read"
+	// ScopeAdmin manages API keys and grants every other scope.
+	ScopeAdmin Scope = "admin"
+)
+
+func (s Scope) IsValid() bool {
+	switch s {
+	case ScopeTeamsRead, ScopeTeamsWrite, ScopeUsersRead, ScopeUsersWrite,
+		ScopePRsRead, ScopePRsWrite, ScopeStatsRead, ScopeAdmin:
+		return true
+	}
+	return false
+}
+
+// APIKey is a service credential; only the hash of its secret is stored.
+type APIKey struct {
+	ID   uuid.UUID
+	Name string
+	// Prefix is the beginning of the secret, shown to tell keys apart.
+	Prefix    string
+	Hash      string
+	Scopes    []Scope
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
+
+func (k APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// UserScopes are granted to people signed in with a token; what they may change is decided per operation.
+var UserScopes = []Scope{
+	ScopeTeamsRead, ScopeTeamsWrite, ScopeUsersRead, ScopeUsersWrite,
+	ScopePRsRead, ScopePRsWrite, ScopeStatsRead,
+}
+
+// Principal is the authenticated caller of a request: a service key, or a user when UserID is set.
+type Principal struct {
+	KeyID    uuid.UUID
+	UserID   uuid.UUID
+	TeamName string
+	Name     string
+	Scopes   []Scope
+}
+
+func (p Principal) IsUser() bool {
+	return p.UserID != uuid.Nil
+}
+
+func (p Principal) HasScope(scope Scope) bool {
+	for _, s := range p.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Unavailability struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	StartsAt  time.Time
+	EndsAt    time.Time
+	Reason    string
+	HandledAt *time.Time
+}
+
+func (u Unavailability) Covers(t time.Time) bool {
+	return !t.Before(u.StartsAt) && t.Before(u.EndsAt)
+}
+
+func (u Unavailability) IsValid() bool {
+	return !u.StartsAt.IsZero() && u.EndsAt.After(u.StartsAt)
+}
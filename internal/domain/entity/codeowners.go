@@ -0,0 +1,174 @@
+package entity
+
+import (
+	"bufio"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+)
+
+type CodeOwnerRule struct {
+	Pattern string
+	Owners  []uuid.UUID
+}
+
+type CodeOwners struct {
+	TeamName string
+	Rules    []CodeOwnerRule
+}
+
+// ParseCodeOwners parses a CODEOWNERS file. Owners are user ids, optionally prefixed with "@".
+func ParseCodeOwners(content string) ([]CodeOwnerRule, error) {
+	var rules []CodeOwnerRule
+
+	sc := bufio.NewScanner(strings.NewReader(content))
+	lineNo := 0
+	for sc.Scan() {
+		lineNo++
+
+		line := sc.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		pattern := fields[0]
+		if err := validateCodeOwnersPattern(pattern); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", common.ErrInvalidCodeOwners, lineNo, err)
+		}
+
+		owners := make([]uuid.UUID, 0, len(fields)-1)
+		for _, f := range fields[1:] {
+			id, err := uuid.Parse(strings.TrimPrefix(f, "@"))
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: invalid owner %q", common.ErrInvalidCodeOwners, lineNo, f)
+			}
+			owners = append(owners, id)
+		}
+
+		rules = append(rules, CodeOwnerRule{Pattern: pattern, Owners: owners})
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", common.ErrInvalidCodeOwners, err)
+	}
+
+	return rules, nil
+}
+
+// OwnersOf returns owners of the given paths in order of first appearance with the paths each one owns.
+// As in GitHub, the last matching rule wins for every path.
+func (c CodeOwners) OwnersOf(paths []string) ([]uuid.UUID, map[uuid.UUID][]string) {
+	var order []uuid.UUID
+	owned := make(map[uuid.UUID][]string)
+
+	for _, p := range paths {
+		rule, ok := c.RuleFor(p)
+		if !ok {
+			continue
+		}
+		for _, id := range rule.Owners {
+			if _, seen := owned[id]; !seen {
+				order = append(order, id)
+			}
+			owned[id] = append(owned[id], p)
+		}
+	}
+
+	return order, owned
+}
+
+func (c CodeOwners) RuleFor(filePath string) (CodeOwnerRule, bool) {
+	for i := len(c.Rules) - 1; i >= 0; i-- {
+		if c.Rules[i].Matches(filePath) {
+			return c.Rules[i], true
+		}
+	}
+	return CodeOwnerRule{}, false
+}
+
+func (r CodeOwnerRule) Matches(filePath string) bool {
+	pattern := r.Pattern
+	dirOnly := strings.HasSuffix(pattern, "/")
+	anchored := strings.HasPrefix(pattern, "/")
+
+	pattern = strings.Trim(pattern, "/")
+	if pattern == "" {
+		return false
+	}
+	if strings.Contains(pattern, "/") {
+		anchored = true
+	}
+
+	patSegs := strings.Split(pattern, "/")
+	if !anchored {
+		patSegs = append([]string{"**"}, patSegs...)
+	}
+
+	segs := strings.Split(strings.Trim(filePath, "/"), "/")
+
+	if !dirOnly && matchSegments(patSegs, segs) {
+		return true
+	}
+
+	// "docs/*" owns direct children only, while "/docs", "docs/" and "**/logs" own the whole subtree.
+	last := patSegs[len(patSegs)-1]
+	if strings.ContainsAny(last, "*?[") {
+		return false
+	}
+	for i := len(segs) - 1; i >= 1; i-- {
+		if matchSegments(patSegs, segs[:i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchSegments(pat, segs []string) bool {
+	if len(pat) == 0 {
+		return len(segs) == 0
+	}
+
+	if pat[0] == "**" {
+		for i := 0; i <= len(segs); i++ {
+			if matchSegments(pat[1:], segs[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(segs) == 0 {
+		return false
+	}
+
+	ok, err := path.Match(pat[0], segs[0])
+	if err != nil || !ok {
+		return false
+	}
+
+	return matchSegments(pat[1:], segs[1:])
+}
+
+func validateCodeOwnersPattern(pattern string) error {
+	if strings.HasPrefix(pattern, "!") {
+		return fmt.Errorf("negated pattern %q is not supported", pattern)
+	}
+
+	for _, seg := range strings.Split(strings.Trim(pattern, "/"), "/") {
+		if _, err := path.Match(seg, ""); err != nil {
+			return fmt.Errorf("bad pattern %q", pattern)
+		}
+	}
+
+	return nil
+}
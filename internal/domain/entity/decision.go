@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AssignmentKind string
+
+const (
+	AssignmentCreate   AssignmentKind = "create"
+	AssignmentReassign AssignmentKind = "reassign"
+)
+
+// ChoiceScore holds the selection criteria of a chosen reviewer in the order they were compared.
+type ChoiceScore struct {
+	RulesMet       int
+	TagsCovered    int
+	InWorkingHours bool
+	// CodeOwnerRank is -1 for users who own none of the changed files.
+	CodeOwnerRank int
+	OpenReviews   int
+	PoolOrder     int
+}
+
+type ExcludedCandidate struct {
+	UserID uuid.UUID
+	Reason string
+}
+
+type AssignmentDecision struct {
+	ID       uuid.UUID
+	PRID     uuid.UUID
+	Kind     AssignmentKind
+	Strategy string
+	// ReplacedUserID is set for reassignments only.
+	ReplacedUserID uuid.UUID
+	Pool           []uuid.UUID
+	Excluded       []ExcludedCandidate
+	Chosen         []ReviewerChoice
+	CreatedAt      time.Time
+}
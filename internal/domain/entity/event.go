@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PREventKind string
+
+const (
+	// EventEscalationReassigned: a stale reviewer was replaced by the escalation job.
+	EventEscalationReassigned PREventKind = "escalation_reassigned"
+	// EventEscalationLeadAdded: the team lead was added as an extra reviewer by the escalation job.
+	EventEscalationLeadAdded PREventKind = "escalation_lead_added"
+	// EventReviewed: the reviewer submitted a review.
+	EventReviewed PREventKind = "reviewed"
+)
+
+type PREvent struct {
+	ID   uuid.UUID
+	PRID uuid.UUID
+	Kind PREventKind
+	// ReviewerID is the reviewer the event is about; NewReviewerID is the one who was assigned.
+	ReviewerID    uuid.UUID
+	NewReviewerID uuid.UUID
+	Detail        string
+	CreatedAt     time.Time
+}
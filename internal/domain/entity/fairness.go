@@ -0,0 +1,199 @@
+package entity
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ActivityChange is a transition of User.IsActive, or with Joined the user becoming a member of the
+// team with IsActive as their state then.
+type ActivityChange struct {
+	UserID    uuid.UUID
+	IsActive  bool
+	Joined    bool
+	ChangedAt time.Time
+}
+
+type MemberFairness struct {
+	UserID   uuid.UUID
+	Username string
+	IsActive bool
+	// AvailableDays excludes days the member was inactive or out of office.
+	AvailableDays float64
+	Assignments   int
+	// IdealShare is the member's part of all assignments, proportional to AvailableDays.
+	IdealShare float64
+	Deviation  float64
+}
+
+type FairnessReport struct {
+	TeamName string
+	From, To time.Time
+	Total    int
+	// Gini is computed over assignments per available day: 0 is perfectly even, 1 is maximally uneven.
+	Gini float64
+	// MaxMinRatio compares the busiest and the least busy available members per available day;
+	// nil when someone available got no assignments at all.
+	MaxMinRatio *float64
+	Members     []MemberFairness
+}
+
+type interval struct {
+	start, end time.Time
+}
+
+// AvailableTime returns how much of [from, to) the member was in the team, active and not on leave.
+// changes must include the first change at or after to, if any: the state before the first known
+// change is the opposite of that change, or current when nothing ever changed. Time before the
+// member's last join is not available.
+func AvailableTime(u User, changes []ActivityChange, leaves []Unavailability, from, to time.Time) time.Duration {
+	sort.Slice(changes, func(i, j int) bool { return changes[i].ChangedAt.Before(changes[j].ChangedAt) })
+
+	var busy []interval
+
+	for i := len(changes) - 1; i >= 0; i-- {
+		c := changes[i]
+		if !c.Joined || !c.ChangedAt.Before(to) {
+			continue
+		}
+		if c.ChangedAt.After(from) {
+			busy = append(busy, interval{from, c.ChangedAt})
+		}
+		changes = changes[i:]
+		break
+	}
+
+	active := u.IsActive
+	switch {
+	case len(changes) == 0:
+	case changes[0].Joined:
+		active = changes[0].IsActive
+	default:
+		active = !changes[0].IsActive
+	}
+
+	inactiveSince := from
+	for _, c := range changes {
+		if !c.ChangedAt.After(from) {
+			active = c.IsActive
+			continue
+		}
+		if !c.ChangedAt.Before(to) {
+			break
+		}
+		if active && !c.IsActive {
+			inactiveSince = c.ChangedAt
+		}
+		if !active && c.IsActive {
+			busy = append(busy, interval{inactiveSince, c.ChangedAt})
+		}
+		active = c.IsActive
+	}
+	if !active {
+		busy = append(busy, interval{inactiveSince, to})
+	}
+
+	for _, l := range leaves {
+		busy = append(busy, interval{l.StartsAt, l.EndsAt})
+	}
+
+	return to.Sub(from) - coveredTime(busy, from, to)
+}
+
+func coveredTime(intervals []interval, from, to time.Time) time.Duration {
+	clipped := make([]interval, 0, len(intervals))
+	for _, iv := range intervals {
+		if iv.start.Before(from) {
+			iv.start = from
+		}
+		if iv.end.After(to) {
+			iv.end = to
+		}
+		if iv.end.After(iv.start) {
+			clipped = append(clipped, iv)
+		}
+	}
+	sort.Slice(clipped, func(i, j int) bool { return clipped[i].start.Before(clipped[j].start) })
+
+	var total time.Duration
+	var cur *interval
+	for i := range clipped {
+		iv := clipped[i]
+		if cur != nil && !iv.start.After(cur.end) {
+			if iv.end.After(cur.end) {
+				cur.end = iv.end
+			}
+			continue
+		}
+		if cur != nil {
+			total += cur.end.Sub(cur.start)
+		}
+		cur = &iv
+	}
+	if cur != nil {
+		total += cur.end.Sub(cur.start)
+	}
+
+	return total
+}
+
+// Finalize fills ideal shares, deviations and the inequality measures from members' assignments and availability.
+func (r *FairnessReport) Finalize() {
+	var totalDays float64
+	r.Total = 0
+	for _, m := range r.Members {
+		r.Total += m.Assignments
+		totalDays += m.AvailableDays
+	}
+
+	var rates []float64
+	for i := range r.Members {
+		m := &r.Members[i]
+		if totalDays > 0 {
+			m.IdealShare = float64(r.Total) * m.AvailableDays / totalDays
+		}
+		m.Deviation = float64(m.Assignments) - m.IdealShare
+		if m.AvailableDays > 0 {
+			rates = append(rates, float64(m.Assignments)/m.AvailableDays)
+		}
+	}
+
+	r.Gini = gini(rates)
+	r.MaxMinRatio = nil
+
+	if len(rates) > 0 {
+		lo, hi := rates[0], rates[0]
+		for _, v := range rates[1:] {
+			lo = math.Min(lo, v)
+			hi = math.Max(hi, v)
+		}
+		if lo > 0 {
+			ratio := hi / lo
+			r.MaxMinRatio = &ratio
+		}
+	}
+}
+
+func gini(values []float64) float64 {
+	n := len(values)
+	if n == 0 {
+		return 0
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	var sum, weighted float64
+	for i, v := range sorted {
+		sum += v
+		weighted += float64(i+1) * v
+	}
+	if sum == 0 {
+		return 0
+	}
+
+	return (2*weighted)/(float64(n)*sum) - float64(n+1)/float64(n)
+}
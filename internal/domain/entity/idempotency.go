@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// IdempotencyRecord remembers the response to a request sent with an Idempotency-Key.
+type IdempotencyRecord struct {
+	// Client is who sent the key; keys of different clients never clash.
+	Client      string
+	Key         string
+	RequestHash string
+	// StatusCode is zero while the original request is still being processed.
+	StatusCode  int
+	ContentType string
+	ETag        string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+func (r IdempotencyRecord) IsPending() bool {
+	return r.StatusCode == 0
+}
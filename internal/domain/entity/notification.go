@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type NotificationKind string
+
+const NotificationDigest NotificationKind = "digest"
+
+type Notification struct {
+	Kind      NotificationKind
+	Recipient User
+	Subject   string
+	Body      string
+	// PullRequests the notification is about, for channels that render them themselves.
+	PullRequests []ReviewAssignment
+	CreatedAt    time.Time
+}
+
+// NewDigest lists the open reviews waiting on the user, oldest assignment first as given.
+func NewDigest(u User, prs []ReviewAssignment, now time.Time) Notification {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Hi %s,\n\nthese pull requests are waiting for your review:\n\n", u.Name)
+	for _, pr := range prs {
+		age := pr.Age(now).Truncate(time.Minute)
+		fmt.Fprintf(&b, "- %s (%s), assigned %s ago\n", pr.Title, pr.PRID, age)
+	}
+
+	return Notification{
+		Kind:         NotificationDigest,
+		Recipient:    u,
+		Subject:      fmt.Sprintf("%d pull request(s) waiting for your review", len(prs)),
+		Body:         b.String(),
+		PullRequests: prs,
+		CreatedAt:    now,
+	}
+}
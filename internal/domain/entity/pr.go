@@ -21,8 +21,66 @@ type PR struct {
 	CreatedAt time.Time
 	MergedAt  *time.Time
 
+	ChangedFiles []string
+	RequiredTags []string
+
 	Reviewers []uuid.UUID
+	// AssignedAt holds when each reviewer was assigned.
+	AssignedAt map[uuid.UUID]time.Time
+
+	// Version grows with every update of the PR and guards against concurrent writes.
+	Version int64
+}
+
+type ReviewerChoice struct {
+	UserID  uuid.UUID
+	Reasons []string
+	Score   ChoiceScore
 }
 
 func (p PR) CanChangeReviewers() bool { return p.Status == StatusOpen }
 func (p PR) IsMerged() bool           { return p.Status == StatusMerged }
+
+// MatchesVersion reports whether the PR is at version; zero matches any version.
+func (p PR) MatchesVersion(version int64) bool {
+	return version == 0 || p.Version == version
+}
+
+func (p PR) ReviewerAssignedAt(id uuid.UUID) time.Time {
+	if at, ok := p.AssignedAt[id]; ok {
+		return at
+	}
+	return p.CreatedAt
+}
+
+func (p PR) HasReviewer(id uuid.UUID) bool {
+	for _, r := range p.Reviewers {
+		if r == id {
+			return true
+		}
+	}
+	return false
+}
+
+// ReviewAssignment is a reviewer waiting on an open PR.
+type ReviewAssignment struct {
+	PRID       uuid.UUID
+	Title      string
+	AuthorID   uuid.UUID
+	ReviewerID uuid.UUID
+	AssignedAt time.Time
+}
+
+func (a ReviewAssignment) Age(now time.Time) time.Duration {
+	return now.Sub(a.AssignedAt)
+}
+
+type OverdueReview struct {
+	Assignment ReviewAssignment
+	Age        time.Duration
+	SLA        time.Duration
+}
+
+func (o OverdueReview) OverdueBy() time.Duration {
+	return o.Age - o.SLA
+}
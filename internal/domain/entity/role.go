@@ -0,0 +1,46 @@
+package entity
+
+import "github.com/google/uuid"
+
+// TeamRole is what a user may do in a team. Members of a team have RoleMember unless granted more.
+type TeamRole string
+
+const (
+	RoleNone   TeamRole = ""
+	RoleMember TeamRole = "member"
+	// RoleLead changes membership, settings and reviewers of the team and activity of its members.
+	RoleLead TeamRole = "lead"
+	// RoleAdmin additionally grants roles in the team.
+	RoleAdmin TeamRole = "admin"
+)
+
+func (r TeamRole) IsValid() bool {
+	switch r {
+	case RoleMember, RoleLead, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+func (r TeamRole) rank() int {
+	switch r {
+	case RoleMember:
+		return 1
+	case RoleLead:
+		return 2
+	case RoleAdmin:
+		return 3
+	}
+	return 0
+}
+
+// AtLeast reports whether r grants everything min does.
+func (r TeamRole) AtLeast(min TeamRole) bool {
+	return r.rank() >= min.rank()
+}
+
+type TeamMemberRole struct {
+	TeamName string
+	UserID   uuid.UUID
+	Role     TeamRole
+}
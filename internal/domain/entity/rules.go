@@ -0,0 +1,120 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+)
+
+type RuleKind string
+
+const (
+	// RuleNever keeps Reviewers off PRs of AuthorID.
+	RuleNever RuleKind = "never"
+	// RuleRequireOneOf requires at least one of Reviewers on PRs of AuthorID.
+	RuleRequireOneOf RuleKind = "require_one_of"
+	// RuleRequireSenior requires at least one senior reviewer; without AuthorID it applies to every PR.
+	RuleRequireSenior RuleKind = "require_senior"
+)
+
+type TeamRule struct {
+	Kind      RuleKind
+	AuthorID  uuid.UUID
+	Reviewers []uuid.UUID
+}
+
+type TeamRules struct {
+	TeamName string
+	Rules    []TeamRule
+}
+
+func (r TeamRule) Validate() error {
+	switch r.Kind {
+	case RuleNever, RuleRequireOneOf:
+		if r.AuthorID == uuid.Nil {
+			return fmt.Errorf("%w: %s rule needs an author", common.ErrInvalidRules, r.Kind)
+		}
+		if len(r.Reviewers) == 0 {
+			return fmt.Errorf("%w: %s rule needs reviewers", common.ErrInvalidRules, r.Kind)
+		}
+	case RuleRequireSenior:
+		if len(r.Reviewers) != 0 {
+			return fmt.Errorf("%w: %s rule takes no reviewers", common.ErrInvalidRules, r.Kind)
+		}
+	default:
+		return fmt.Errorf("%w: unknown rule kind %q", common.ErrInvalidRules, r.Kind)
+	}
+	return nil
+}
+
+func (r TeamRule) AppliesTo(authorID uuid.UUID) bool {
+	return r.AuthorID == uuid.Nil || r.AuthorID == authorID
+}
+
+func (r TeamRule) IsRequirement() bool {
+	return r.Kind == RuleRequireOneOf || r.Kind == RuleRequireSenior
+}
+
+// SatisfiedBy reports whether u as a reviewer fulfils a requirement rule.
+func (r TeamRule) SatisfiedBy(u User) bool {
+	switch r.Kind {
+	case RuleRequireOneOf:
+		for _, id := range r.Reviewers {
+			if id == u.ID {
+				return true
+			}
+		}
+	case RuleRequireSenior:
+		return u.IsSenior
+	}
+	return false
+}
+
+func (r TeamRule) String() string {
+	ids := make([]string, 0, len(r.Reviewers))
+	for _, id := range r.Reviewers {
+		ids = append(ids, id.String())
+	}
+
+	switch r.Kind {
+	case RuleNever:
+		return fmt.Sprintf("never assign %s to PRs of %s", strings.Join(ids, ", "), r.AuthorID)
+	case RuleRequireOneOf:
+		return fmt.Sprintf("one of %s must review PRs of %s", strings.Join(ids, ", "), r.AuthorID)
+	case RuleRequireSenior:
+		if r.AuthorID != uuid.Nil {
+			return fmt.Sprintf("at least one senior must review PRs of %s", r.AuthorID)
+		}
+		return "at least one senior must review every PR"
+	}
+	return string(r.Kind)
+}
+
+// Forbidden returns reviewers that must never be assigned to PRs of authorID with the rule forbidding each.
+func (rs TeamRules) Forbidden(authorID uuid.UUID) map[uuid.UUID]TeamRule {
+	res := make(map[uuid.UUID]TeamRule)
+	for _, r := range rs.Rules {
+		if r.Kind != RuleNever || !r.AppliesTo(authorID) {
+			continue
+		}
+		for _, id := range r.Reviewers {
+			if _, ok := res[id]; !ok {
+				res[id] = r
+			}
+		}
+	}
+	return res
+}
+
+func (rs TeamRules) Requirements(authorID uuid.UUID) []TeamRule {
+	var res []TeamRule
+	for _, r := range rs.Rules {
+		if r.IsRequirement() && r.AppliesTo(authorID) {
+			res = append(res, r)
+		}
+	}
+	return res
+}
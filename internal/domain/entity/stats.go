@@ -1,10 +1,171 @@
 package entity
 
-import "github.com/google/uuid"
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type ReviewerStats struct {
 	UserID          uuid.UUID
 	Username        string
 	TeamName        string
+	IsActive        bool
 	AssignedOpenPRs int
+	// TotalReviews counts every assignment of the user, including those reassigned away, as
+	// ReviewTotals.TotalAssignments does; MergedReviews counts current reviews of merged PRs.
+	TotalReviews   int
+	MergedReviews  int
+	LastAssignedAt *time.Time
+}
+
+type ReviewerStatsSort string
+
+const (
+	SortByOpen         ReviewerStatsSort = "open"
+	SortByTotal        ReviewerStatsSort = "total"
+	SortByMerged       ReviewerStatsSort = "merged"
+	SortByLastAssigned ReviewerStatsSort = "last_assigned"
+	SortByName         ReviewerStatsSort = "name"
+)
+
+func (s ReviewerStatsSort) IsValid() bool {
+	switch s {
+	case SortByOpen, SortByTotal, SortByMerged, SortByLastAssigned, SortByName:
+		return true
+	}
+	return false
+}
+
+// SortReviewerStats orders by name ascending or by the chosen count descending, ties broken by name.
+// Users never assigned come last when sorting by last_assigned.
+func SortReviewerStats(stats []ReviewerStats, by ReviewerStatsSort) {
+	key := func(s ReviewerStats) int64 {
+		switch by {
+		case SortByTotal:
+			return int64(s.TotalReviews)
+		case SortByMerged:
+			return int64(s.MergedReviews)
+		case SortByLastAssigned:
+			if s.LastAssignedAt == nil {
+				return math.MinInt64
+			}
+			return s.LastAssignedAt.UnixNano()
+		case SortByName:
+			return 0
+		default:
+			return int64(s.AssignedOpenPRs)
+		}
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool {
+		if ki, kj := key(stats[i]), key(stats[j]); ki != kj {
+			return ki > kj
+		}
+		if stats[i].Username != stats[j].Username {
+			return stats[i].Username < stats[j].Username
+		}
+		return stats[i].UserID.String() < stats[j].UserID.String()
+	})
+}
+
+// DurationSummary uses nearest-rank percentiles, so Median and P90 are always observed values.
+type DurationSummary struct {
+	Count  int
+	Median time.Duration
+	P90    time.Duration
+}
+
+func SummarizeDurations(ds []time.Duration) DurationSummary {
+	if len(ds) == 0 {
+		return DurationSummary{}
+	}
+
+	sorted := append([]time.Duration(nil), ds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	return DurationSummary{
+		Count:  len(sorted),
+		Median: percentile(sorted, 0.5),
+		P90:    percentile(sorted, 0.9),
+	}
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	return sorted[idx]
+}
+
+// PRTimes is the lifecycle of a PR as used by history metrics.
+type PRTimes struct {
+	PRID      uuid.UUID
+	CreatedAt time.Time
+	MergedAt  *time.Time
+}
+
+// FirstReview is when a reviewer first reviewed a PR they are assigned to.
+type FirstReview struct {
+	ReviewerID uuid.UUID
+	Username   string
+	PRID       uuid.UUID
+	AssignedAt time.Time
+	ReviewedAt time.Time
+}
+
+type WeeklyThroughput struct {
+	// WeekStart is Monday 00:00 UTC.
+	WeekStart time.Time
+	Opened    int
+	Merged    int
+}
+
+type ReviewerLatency struct {
+	UserID      uuid.UUID
+	Username    string
+	FirstReview DurationSummary
+}
+
+type TeamStats struct {
+	TeamName    string
+	From, To    time.Time
+	TimeToMerge DurationSummary
+	Throughput  []WeeklyThroughput
+	Reviewers   []ReviewerLatency
+}
+
+// WeekStart returns Monday 00:00 UTC of the week containing t.
+func WeekStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// ReviewTotals aggregates a user's work as a reviewer over all time.
+type ReviewTotals struct {
+	OpenAssignments int
+	// TotalAssignments counts current assignments plus those the user was reassigned away from.
+	TotalAssignments int
+	ReassignedAway   int
+	// AvgOpenTime is the mean created-to-merged time of merged PRs the user reviewed, nil if there are none.
+	AvgOpenTime *time.Duration
+}
+
+type AuthorTotals struct {
+	Authored int
+	Merged   int
+}
+
+type UserStats struct {
+	UserID   uuid.UUID
+	Username string
+	TeamName string
+	IsActive bool
+	Reviews  ReviewTotals
+	Authored AuthorTotals
 }
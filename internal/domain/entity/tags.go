@@ -0,0 +1,23 @@
+package entity
+
+import "strings"
+
+// NormalizeTags lowercases and trims tags, dropping empty and duplicate ones.
+func NormalizeTags(tags []string) []string {
+	res := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" {
+			continue
+		}
+		if _, dup := seen[t]; dup {
+			continue
+		}
+		seen[t] = struct{}{}
+		res = append(res, t)
+	}
+
+	return res
+}
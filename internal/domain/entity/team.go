@@ -1,5 +1,101 @@
 package entity
 
+import (
+	"fmt"
+	"time"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+)
+
 type Team struct {
 	Name string
 }
+
+type CapacityPolicy string
+
+const (
+	// CapacityStrict fails the assignment when every candidate is at capacity.
+	CapacityStrict CapacityPolicy = "strict"
+	// CapacityOverAssign assigns the least-loaded candidates above their limit.
+	CapacityOverAssign CapacityPolicy = "over_assign"
+)
+
+func (p CapacityPolicy) IsValid() bool {
+	return p == CapacityStrict || p == CapacityOverAssign
+}
+
+type EscalationPolicy string
+
+const (
+	// EscalationReassign replaces the stale reviewer with another candidate.
+	EscalationReassign EscalationPolicy = "reassign"
+	// EscalationAddLead adds the team lead as an extra reviewer.
+	EscalationAddLead EscalationPolicy = "add_lead"
+)
+
+func (p EscalationPolicy) IsValid() bool {
+	return p == EscalationReassign || p == EscalationAddLead
+}
+
+type TeamSettings struct {
+	TeamName           string
+	ReassignOnLeave    bool
+	PreferWorkingHours bool
+	CapacityPolicy     CapacityPolicy
+	// ReviewSLA is how long a review may wait after assignment; zero disables overdue tracking.
+	ReviewSLA time.Duration
+	// EscalateAfter is how long a review may wait before the escalation job acts; zero disables escalation.
+	EscalateAfter    time.Duration
+	EscalationPolicy EscalationPolicy
+}
+
+func DefaultTeamSettings(teamName string) TeamSettings {
+	return TeamSettings{TeamName: teamName, CapacityPolicy: CapacityStrict, EscalationPolicy: EscalationReassign}
+}
+
+func (s TeamSettings) Validate() error {
+	if !s.CapacityPolicy.IsValid() {
+		return fmt.Errorf("%w: unknown capacity policy %q", common.ErrInvalidTeamSettings, s.CapacityPolicy)
+	}
+	if s.ReviewSLA < 0 {
+		return fmt.Errorf("%w: review SLA must not be negative", common.ErrInvalidTeamSettings)
+	}
+	if !s.EscalationPolicy.IsValid() {
+		return fmt.Errorf("%w: unknown escalation policy %q", common.ErrInvalidTeamSettings, s.EscalationPolicy)
+	}
+	if s.EscalateAfter < 0 {
+		return fmt.Errorf("%w: escalation threshold must not be negative", common.ErrInvalidTeamSettings)
+	}
+	return nil
+}
+
+type TeamSettingsPatch struct {
+	ReassignOnLeave    *bool
+	PreferWorkingHours *bool
+	CapacityPolicy     *CapacityPolicy
+	ReviewSLA          *time.Duration
+	EscalateAfter      *time.Duration
+	EscalationPolicy   *EscalationPolicy
+}
+
+func (s TeamSettings) Apply(p TeamSettingsPatch) TeamSettings {
+	if p.ReassignOnLeave != nil {
+		s.ReassignOnLeave = *p.ReassignOnLeave
+	}
+	if p.PreferWorkingHours != nil {
+		s.PreferWorkingHours = *p.PreferWorkingHours
+	}
+	if p.CapacityPolicy != nil {
+		s.CapacityPolicy = *p.CapacityPolicy
+	}
+	if p.ReviewSLA != nil {
+		s.ReviewSLA = *p.ReviewSLA
+	}
+	if p.EscalateAfter != nil {
+		s.EscalateAfter = *p.EscalateAfter
+	}
+	if p.EscalationPolicy != nil {
+		s.EscalationPolicy = *p.EscalationPolicy
+	}
+	return s
+}
@@ -9,4 +9,25 @@ type User struct {
 	TeamName string
 	Name     string
 	IsActive bool
+	Skills   []string
+	IsSenior bool
+	// Email receives notifications; users without one get no emails.
+	Email string
+
+	WorkingHours *WorkingHours
+	// MaxOpenReviews limits how many open PRs the user reviews at once; nil means unlimited.
+	MaxOpenReviews *int
+}
+
+func (u User) AtCapacity(openReviews int) bool {
+	return u.MaxOpenReviews != nil && openReviews >= *u.MaxOpenReviews
+}
+
+func (u User) HasSkill(tag string) bool {
+	for _, s := range u.Skills {
+		if s == tag {
+			return true
+		}
+	}
+	return false
 }
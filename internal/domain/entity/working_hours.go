@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+)
+
+type WorkingHours struct {
+	Timezone string
+	// StartMinute and EndMinute are minutes since local midnight; End < Start means an overnight shift.
+	StartMinute int
+	EndMinute   int
+	Days        []time.Weekday
+}
+
+func DefaultWorkDays() []time.Weekday {
+	return []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+}
+
+func (w WorkingHours) Validate() error {
+	if _, err := time.LoadLocation(w.Timezone); err != nil || w.Timezone == "" {
+		return fmt.Errorf("%w: unknown timezone %q", common.ErrInvalidWorkingHours, w.Timezone)
+	}
+	if w.StartMinute < 0 || w.StartMinute >= 24*60 || w.EndMinute < 0 || w.EndMinute >= 24*60 {
+		return fmt.Errorf("%w: hours must be within a day", common.ErrInvalidWorkingHours)
+	}
+	if w.StartMinute == w.EndMinute {
+		return fmt.Errorf("%w: start and end must differ", common.ErrInvalidWorkingHours)
+	}
+	if len(w.Days) == 0 {
+		return fmt.Errorf("%w: at least one working day is required", common.ErrInvalidWorkingHours)
+	}
+	return nil
+}
+
+// Contains reports whether t falls into working hours in the user's timezone.
+// An overnight shift belongs to the day it starts on.
+func (w WorkingHours) Contains(t time.Time) bool {
+	loc, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return false
+	}
+
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	day := local.Weekday()
+
+	if w.StartMinute < w.EndMinute {
+		return w.hasDay(day) && minute >= w.StartMinute && minute < w.EndMinute
+	}
+
+	if minute >= w.StartMinute {
+		return w.hasDay(day)
+	}
+	return minute < w.EndMinute && w.hasDay((day+6)%7)
+}
+
+func (w WorkingHours) String() string {
+	return fmt.Sprintf("%s-%s %s", FormatClock(w.StartMinute), FormatClock(w.EndMinute), w.Timezone)
+}
+
+func (w WorkingHours) hasDay(d time.Weekday) bool {
+	for _, wd := range w.Days {
+		if wd == d {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseClock parses "HH:MM" into minutes since midnight.
+func ParseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid time %q", common.ErrInvalidWorkingHours, s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func FormatClock(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+func ParseWeekday(s string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := d.String()
+		if strings.EqualFold(s, name) || strings.EqualFold(s, name[:3]) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: invalid weekday %q", common.ErrInvalidWorkingHours, s)
+}
@@ -0,0 +1,34 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// New builds a logger writing to w. Level is one of debug, info, warn or error (info if empty);
+// format is json or text (json if empty).
+func New(w io.Writer, level, format string) (*slog.Logger, error) {
+	var lvl slog.Level
+	if level != "" {
+		if err := lvl.UnmarshalText([]byte(level)); err != nil {
+			return nil, fmt.Errorf("invalid log level %q", level)
+		}
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl}
+
+	switch format {
+	case "", FormatJSON:
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	case FormatText:
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	default:
+		return nil, fmt.Errorf("invalid log format %q", format)
+	}
+}
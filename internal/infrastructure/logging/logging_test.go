@@ -0,0 +1,50 @@
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/infrastructure/logging"
+)
+
+func TestNew(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger, err := logging.New(&buf, "warn", logging.FormatJSON)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	logger.Info("dropped")
+	logger.Warn("kept", "team", "backend")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected only the warn line, got %q", buf.String())
+	}
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("line is not JSON: %v", err)
+	}
+	if entry["msg"] != "kept" || entry["team"] != "backend" {
+		t.Errorf("unexpected entry: %v", entry)
+	}
+
+	buf.Reset()
+	logger, err = logging.New(&buf, "", logging.FormatText)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	logger.Info("hello")
+	if !strings.Contains(buf.String(), "msg=hello") {
+		t.Errorf("expected text output, got %q", buf.String())
+	}
+
+	if _, err := logging.New(&buf, "loud", ""); err == nil {
+		t.Errorf("expected error for invalid level")
+	}
+	if _, err := logging.New(&buf, "", "xml"); err == nil {
+		t.Errorf("expected error for invalid format")
+	}
+}
@@ -0,0 +1,143 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "prservice"
+
+// Prometheus keeps all service metrics in its own registry, exposed by Handler.
+type Prometheus struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	prsCreated    prometheus.Counter
+	prsMerged     prometheus.Counter
+	reassignments prometheus.Counter
+	noCandidate   prometheus.Counter
+	openPRs       *prometheus.GaugeVec
+}
+
+func New() *Prometheus {
+	p := &Prometheus{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, route pattern and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method and route pattern.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		prsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "prs_created_total",
+			Help:      "Pull requests created.",
+		}),
+		prsMerged: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "prs_merged_total",
+			Help:      "Pull requests merged.",
+		}),
+		reassignments: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reviewer_reassignments_total",
+			Help:      "Reviewers replaced manually, on leave or by escalation.",
+		}),
+		noCandidate: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "no_candidate_total",
+			Help:      "Assignments that failed because no reviewer candidate was available.",
+		}),
+		openPRs: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "open_prs",
+			Help:      "Open pull requests by author team, refreshed periodically.",
+		}, []string{"team"}),
+	}
+
+	p.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		p.httpRequests,
+		p.httpDuration,
+		p.prsCreated,
+		p.prsMerged,
+		p.reassignments,
+		p.noCandidate,
+		p.openPRs,
+	)
+
+	return p
+}
+
+func (p *Prometheus) Handler() http.Handler {
+	return promhttp.HandlerFor(p.registry, promhttp.HandlerOpts{Registry: p.registry})
+}
+
+func (p *Prometheus) ObserveRequest(method, route string, status int, elapsed time.Duration) {
+	p.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	p.httpDuration.WithLabelValues(method, route).Observe(elapsed.Seconds())
+}
+
+// RegisterDBStats exposes connection pool stats, read from stats on every scrape.
+func (p *Prometheus) RegisterDBStats(stats func() sql.DBStats) {
+	gauge := func(name, help string, v func(sql.DBStats) float64) prometheus.Collector {
+		return prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      name,
+			Help:      help,
+		}, func() float64 { return v(stats()) })
+	}
+	counter := func(name, help string, v func(sql.DBStats) float64) prometheus.Collector {
+		return prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      name,
+			Help:      help,
+		}, func() float64 { return v(stats()) })
+	}
+
+	p.registry.MustRegister(
+		gauge("max_open_connections", "Maximum number of open connections.",
+			func(s sql.DBStats) float64 { return float64(s.MaxOpenConnections) }),
+		gauge("open_connections", "Established connections, in use and idle.",
+			func(s sql.DBStats) float64 { return float64(s.OpenConnections) }),
+		gauge("in_use_connections", "Connections currently in use.",
+			func(s sql.DBStats) float64 { return float64(s.InUse) }),
+		gauge("idle_connections", "Idle connections.",
+			func(s sql.DBStats) float64 { return float64(s.Idle) }),
+		counter("wait_count_total", "Connections waited for.",
+			func(s sql.DBStats) float64 { return float64(s.WaitCount) }),
+		counter("wait_duration_seconds_total", "Time blocked waiting for a connection.",
+			func(s sql.DBStats) float64 { return s.WaitDuration.Seconds() }),
+		counter("max_idle_closed_total", "Connections closed due to SetMaxIdleConns.",
+			func(s sql.DBStats) float64 { return float64(s.MaxIdleClosed) }),
+		counter("max_idle_time_closed_total", "Connections closed due to SetConnMaxIdleTime.",
+			func(s sql.DBStats) float64 { return float64(s.MaxIdleTimeClosed) }),
+		counter("max_lifetime_closed_total", "Connections closed due to SetConnMaxLifetime.",
+			func(s sql.DBStats) float64 { return float64(s.MaxLifetimeClosed) }),
+	)
+}
+
+func (p *Prometheus) PRCreated()          { p.prsCreated.Inc() }
+func (p *Prometheus) PRMerged()           { p.prsMerged.Inc() }
+func (p *Prometheus) ReviewerReassigned() { p.reassignments.Inc() }
+func (p *Prometheus) NoCandidate()        { p.noCandidate.Inc() }
+
+func (p *Prometheus) SetOpenPRs(teamName string, n int) {
+	p.openPRs.WithLabelValues(teamName).Set(float64(n))
+}
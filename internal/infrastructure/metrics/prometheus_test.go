@@ -0,0 +1,77 @@
+package metrics_test
+
+import (
+	"database/sql"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/infrastructure/metrics"
+	httpserver "github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/handler"
+)
+
+func TestPrometheus_ExposesRouteAndDomainMetrics(t *testing.T) {
+	logger := slog.New(slog.DiscardHandler)
+	prom := metrics.New()
+	prom.RegisterDBStats(func() sql.DBStats { return sql.DBStats{OpenConnections: 3, InUse: 1} })
+
+	router := httpserver.NewRouter(
+		httpserver.Handlers{
+			Team:    handler.NewTeamHandler(nil, logger),
+			User:    handler.NewUserHandler(nil, logger),
+			PR:      handler.NewPRHandler(nil, logger),
+			Stats:   handler.NewStatsHandler(nil, logger),
+			APIKeys: handler.NewAPIKeyHandler(nil, logger),
+			Health:  handler.NewHealthHandler(),
+		},
+		httpserver.Middlewares{},
+		prom,
+		logger,
+	)
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	for _, path := range []string{"/stats/user?user_id=a", "/stats/user?user_id=b", "/nope"} {
+		res, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		_ = res.Body.Close()
+	}
+
+	prom.PRCreated()
+	prom.NoCandidate()
+	prom.SetOpenPRs("backend", 4)
+
+	res, err := http.Get(srv.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("GET /metrics: %v", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	out := string(body)
+
+	for _, want := range []string{
+		`prservice_http_requests_total{method="GET",route="/stats/user",status="400"} 2`,
+		`prservice_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`prservice_http_request_duration_seconds_count{method="GET",route="/stats/user"} 2`,
+		`prservice_prs_created_total 1`,
+		`prservice_no_candidate_total 1`,
+		`prservice_open_prs{team="backend"} 4`,
+		`prservice_db_open_connections 3`,
+		`prservice_db_in_use_connections 1`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("metrics output missing %q", want)
+		}
+	}
+}
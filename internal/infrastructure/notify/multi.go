@@ -0,0 +1,22 @@
+package notify
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/app"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+// Multi delivers every notification through all of its notifiers.
+type Multi []app.Notifier
+
+func (m Multi) Notify(ctx context.Context, n entity.Notification) error {
+	var errs []error
+	for _, notifier := range m {
+		if err := notifier.Notify(ctx, n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,181 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+type receivedMail struct {
+	from string
+	to   []string
+	data string
+}
+
+// startFakeSMTP accepts a single session on a local port and reports what was sent.
+func startFakeSMTP(t *testing.T) (string, int, <-chan receivedMail) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	mails := make(chan receivedMail, 1)
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		r := bufio.NewReader(conn)
+		reply := func(s string) { _, _ = conn.Write([]byte(s + "\r\n")) }
+
+		var m receivedMail
+		reply("220 fake ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.TrimRight(line, "\r\n")
+			upper := strings.ToUpper(cmd)
+
+			switch {
+			case strings.HasPrefix(upper, "EHLO"), strings.HasPrefix(upper, "HELO"):
+				reply("250 fake")
+			case strings.HasPrefix(upper, "MAIL FROM:"):
+				m.from = strings.Trim(cmd[len("MAIL FROM:"):], "<> ")
+				reply("250 OK")
+			case strings.HasPrefix(upper, "RCPT TO:"):
+				m.to = append(m.to, strings.Trim(cmd[len("RCPT TO:"):], "<> "))
+				reply("250 OK")
+			case upper == "DATA":
+				reply("354 go ahead")
+				var data strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					data.WriteString(l)
+				}
+				m.data = data.String()
+				reply("250 queued")
+			case upper == "QUIT":
+				reply("221 bye")
+				mails <- m
+				return
+			default:
+				reply("502 not implemented")
+			}
+		}
+	}()
+
+	addr := ln.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port, mails
+}
+
+func TestSMTP_Notify(t *testing.T) {
+	host, port, mails := startFakeSMTP(t)
+
+	n := NewSMTP(SMTPConfig{Host: host, Port: port, From: "pr-bot@example.com"})
+
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	user := entity.User{ID: uuid.New(), Name: "Alice", Email: "alice@example.com"}
+	pr := entity.ReviewAssignment{PRID: uuid.New(), Title: "Add search", AssignedAt: now.Add(-3 * time.Hour)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := n.Notify(ctx, entity.NewDigest(user, []entity.ReviewAssignment{pr}, now)); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+
+	select {
+	case m := <-mails:
+		if m.from != "pr-bot@example.com" {
+			t.Errorf("from: got %q", m.from)
+		}
+		if len(m.to) != 1 || m.to[0] != "alice@example.com" {
+			t.Errorf("to: got %v", m.to)
+		}
+		if !strings.Contains(m.data, "Subject: 1 pull request(s) waiting for your review") {
+			t.Errorf("subject missing in %q", m.data)
+		}
+		if !strings.Contains(m.data, "- Add search ("+pr.PRID.String()+"), assigned 3h0m0s ago") {
+			t.Errorf("digest line missing in %q", m.data)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("fake SMTP server received nothing")
+	}
+}
+
+func TestSMTP_Notify_SkipsUsersWithoutEmail(t *testing.T) {
+	// Nothing listens on the port: a delivery attempt would fail.
+	n := NewSMTP(SMTPConfig{Host: "127.0.0.1", Port: 1, From: "pr-bot@example.com"})
+
+	user := entity.User{ID: uuid.New(), Name: "Bob"}
+	if err := n.Notify(context.Background(), entity.NewDigest(user, nil, time.Now())); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+}
+
+func TestSMTP_Notify_Timeout(t *testing.T) {
+	// The server accepts the connection but never greets.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		t.Cleanup(func() { _ = conn.Close() })
+	}()
+
+	addr := ln.Addr().(*net.TCPAddr)
+	n := NewSMTP(SMTPConfig{Host: addr.IP.String(), Port: addr.Port, From: "pr-bot@example.com", Timeout: 100 * time.Millisecond})
+
+	user := entity.User{ID: uuid.New(), Name: "Alice", Email: "alice@example.com"}
+
+	done := make(chan error, 1)
+	go func() { done <- n.Notify(context.Background(), entity.NewDigest(user, nil, time.Now())) }()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatalf("expected a timeout error from a stalled server")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Notify did not return on a stalled server")
+	}
+}
+
+func TestSMTP_Message(t *testing.T) {
+	n := NewSMTP(SMTPConfig{Host: "localhost", Port: 25, From: "bot@example.com"})
+
+	msg := string(n.message("a@example.com", entity.Notification{Subject: "Привет", Body: "a\nb\n"}))
+
+	if !strings.Contains(msg, "Subject: =?utf-8?q?") {
+		t.Errorf("non-ASCII subject must be encoded: %q", msg)
+	}
+	if !strings.HasSuffix(msg, "\r\n\r\na\r\nb\r\n") {
+		t.Errorf("body must use CRLF line endings: %q", msg)
+	}
+}
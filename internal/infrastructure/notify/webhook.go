@@ -0,0 +1,87 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+type webhookPR struct {
+	PullRequestID   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	AssignedAt      time.Time `json:"assigned_at"`
+}
+
+type webhookPayload struct {
+	Kind         string      `json:"kind"`
+	UserID       string      `json:"user_id"`
+	Username     string      `json:"username"`
+	TeamName     string      `json:"team_name"`
+	Email        string      `json:"email,omitempty"`
+	Subject      string      `json:"subject"`
+	Body         string      `json:"body"`
+	PullRequests []webhookPR `json:"pull_requests"`
+	CreatedAt    time.Time   `json:"created_at"`
+}
+
+// Webhook POSTs notifications as JSON; any non-2xx response is an error.
+type Webhook struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhook(url string, timeout time.Duration) *Webhook {
+	return &Webhook{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (h *Webhook) Notify(ctx context.Context, n entity.Notification) error {
+	payload := webhookPayload{
+		Kind:         string(n.Kind),
+		UserID:       n.Recipient.ID.String(),
+		Username:     n.Recipient.Name,
+		TeamName:     n.Recipient.TeamName,
+		Email:        n.Recipient.Email,
+		Subject:      n.Subject,
+		Body:         n.Body,
+		PullRequests: make([]webhookPR, 0, len(n.PullRequests)),
+		CreatedAt:    n.CreatedAt,
+	}
+	for _, pr := range n.PullRequests {
+		payload.PullRequests = append(payload.PullRequests, webhookPR{
+			PullRequestID:   pr.PRID.String(),
+			PullRequestName: pr.Title,
+			AssignedAt:      pr.AssignedAt,
+		})
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with %s", res.Status)
+	}
+
+	return nil
+}
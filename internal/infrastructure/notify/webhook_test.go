@@ -0,0 +1,60 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+func TestWebhook_Notify(t *testing.T) {
+	var got webhookPayload
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	user := entity.User{ID: uuid.New(), TeamName: "backend", Name: "Alice"}
+	pr := entity.ReviewAssignment{PRID: uuid.New(), Title: "Add search", AssignedAt: now.Add(-time.Hour)}
+
+	n := NewWebhook(srv.URL, time.Second)
+	if err := n.Notify(context.Background(), entity.NewDigest(user, []entity.ReviewAssignment{pr}, now)); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+
+	if got.Kind != "digest" || got.UserID != user.ID.String() || got.TeamName != "backend" {
+		t.Fatalf("unexpected payload %+v", got)
+	}
+	if len(got.PullRequests) != 1 || got.PullRequests[0].PullRequestID != pr.PRID.String() {
+		t.Fatalf("unexpected pull requests %+v", got.PullRequests)
+	}
+	if !got.PullRequests[0].AssignedAt.Equal(pr.AssignedAt) {
+		t.Fatalf("assigned_at: got %v, want %v", got.PullRequests[0].AssignedAt, pr.AssignedAt)
+	}
+}
+
+func TestWebhook_Notify_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	n := NewWebhook(srv.URL, time.Second)
+	if err := n.Notify(context.Background(), entity.Notification{Kind: entity.NotificationDigest}); err == nil {
+		t.Fatalf("expected an error for a 502 response")
+	}
+}
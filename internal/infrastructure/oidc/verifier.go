@@ -0,0 +1,87 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/MicahParks/keyfunc/v3"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+)
+
+type Config struct {
+	// JWKSURL is fetched and refreshed in the background; JWKSFile is read once. One of them is required.
+	JWKSURL  string
+	JWKSFile string
+	Issuer   string
+	Audience string
+	// SubjectClaim holds the user ID; empty means "sub".
+	SubjectClaim string
+}
+
+// Verifier checks JWT signatures against a JWKS and the issuer, audience and expiry claims.
+type Verifier struct {
+	keys   keyfunc.Keyfunc
+	parser *jwt.Parser
+	claim  string
+}
+
+func New(ctx context.Context, cfg Config) (*Verifier, error) {
+	var (
+		keys keyfunc.Keyfunc
+		err  error
+	)
+	switch {
+	case cfg.JWKSURL != "":
+		keys, err = keyfunc.NewDefaultCtx(ctx, []string{cfg.JWKSURL})
+	case cfg.JWKSFile != "":
+		var raw []byte
+		raw, err = os.ReadFile(cfg.JWKSFile)
+		if err == nil {
+			keys, err = keyfunc.NewJWKSetJSON(raw)
+		}
+	default:
+		return nil, errors.New("jwks url or file is required")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load jwks: %w", err)
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "PS256", "EdDSA"}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(30 * time.Second),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+
+	claim := cfg.SubjectClaim
+	if claim == "" {
+		claim = "sub"
+	}
+
+	return &Verifier{keys: keys, parser: jwt.NewParser(opts...), claim: claim}, nil
+}
+
+// Verify returns the subject of a valid token; any validation failure wraps common.ErrUnauthorized.
+func (v *Verifier) Verify(ctx context.Context, token string) (string, error) {
+	claims := jwt.MapClaims{}
+	if _, err := v.parser.ParseWithClaims(token, claims, v.keys.KeyfuncCtx(ctx)); err != nil {
+		return "", fmt.Errorf("%w: %v", common.ErrUnauthorized, err)
+	}
+
+	sub, _ := claims[v.claim].(string)
+	if sub == "" {
+		return "", fmt.Errorf("%w: missing %s claim", common.ErrUnauthorized, v.claim)
+	}
+
+	return sub, nil
+}
@@ -0,0 +1,165 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/infrastructure/oidc"
+)
+
+const (
+	testKID      = "test-key"
+	testIssuer   = "https://idp.example.com"
+	testAudience = "pr-reviewer-service"
+)
+
+func jwksJSON(t *testing.T, key *rsa.PublicKey) []byte {
+	t.Helper()
+
+	b64 := base64.RawURLEncoding.EncodeToString
+	raw, err := json.Marshal(map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": testKID,
+			"alg": "RS256",
+			"use": "sig",
+			"n":   b64(key.N.Bytes()),
+			"e":   b64(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	})
+	if err != nil {
+		t.Fatalf("marshal jwks: %v", err)
+	}
+	return raw
+}
+
+func sign(t *testing.T, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = testKID
+	s, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return s
+}
+
+func TestVerifier_JWKSServer(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	jwks := jwksJSON(t, &key.PublicKey)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(jwks)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	v, err := oidc.New(ctx, oidc.Config{JWKSURL: srv.URL, Issuer: testIssuer, Audience: testAudience})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub": "8a6e0804-2bd0-4672-b79d-d97027f9071a",
+			"iss": testIssuer,
+			"aud": testAudience,
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}
+	}
+	with := func(k string, val any) jwt.MapClaims {
+		c := valid()
+		if val == nil {
+			delete(c, k)
+		} else {
+			c[k] = val
+		}
+		return c
+	}
+
+	sub, err := v.Verify(ctx, sign(t, key, valid()))
+	if err != nil {
+		t.Fatalf("valid token: unexpected error: %v", err)
+	}
+	if sub != "8a6e0804-2bd0-4672-b79d-d97027f9071a" {
+		t.Fatalf("subject: got %q", sub)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "expired", token: sign(t, key, with("exp", time.Now().Add(-time.Hour).Unix()))},
+		{name: "no expiry", token: sign(t, key, with("exp", nil))},
+		{name: "wrong audience", token: sign(t, key, with("aud", "other-service"))},
+		{name: "wrong issuer", token: sign(t, key, with("iss", "https://evil.example.com"))},
+		{name: "missing subject", token: sign(t, key, with("sub", nil))},
+		{name: "foreign key", token: sign(t, otherKey, valid())},
+		{name: "garbage", token: "a.b.c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := v.Verify(ctx, tt.token); !errors.Is(err, common.ErrUnauthorized) {
+				t.Fatalf("expected ErrUnauthorized, got %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifier_JWKSFile(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, jwksJSON(t, &key.PublicKey), 0o600); err != nil {
+		t.Fatalf("write jwks: %v", err)
+	}
+
+	v, err := oidc.New(context.Background(), oidc.Config{JWKSFile: path, SubjectClaim: "uid"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	sub, err := v.Verify(context.Background(), sign(t, key, jwt.MapClaims{
+		"uid": "b2f3c1d0-0000-4000-8000-000000000001",
+		"exp": time.Now().Add(time.Minute).Unix(),
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sub != "b2f3c1d0-0000-4000-8000-000000000001" {
+		t.Fatalf("subject: got %q", sub)
+	}
+
+	if _, err := oidc.New(context.Background(), oidc.Config{}); err == nil {
+		t.Fatalf("expected error without a jwks source")
+	}
+}
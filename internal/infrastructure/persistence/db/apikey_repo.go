@@ -0,0 +1,146 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+type APIKeyRepo struct {
+	db *DB
+}
+
+func NewAPIKeyRepo(db *DB) *APIKeyRepo {
+	return &APIKeyRepo{db: db}
+}
+
+func (r *APIKeyRepo) Create(ctx context.Context, key entity.APIKey) error {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		INSERT INTO api_keys (id, name, prefix, key_hash, scopes, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	scopes := make([]string, 0, len(key.Scopes))
+	for _, s := range key.Scopes {
+		scopes = append(scopes, string(s))
+	}
+
+	_, err := e.ExecContext(ctx, q,
+		key.ID,
+		key.Name,
+		key.Prefix,
+		key.Hash,
+		pq.Array(scopes),
+		key.CreatedAt,
+	)
+	return err
+}
+
+func (r *APIKeyRepo) GetByHash(ctx context.Context, hash string) (entity.APIKey, error) {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		SELECT id, name, prefix, key_hash, scopes, created_at, revoked_at
+		FROM api_keys
+		WHERE key_hash = $1
+	`
+
+	key, err := scanAPIKey(e.QueryRowContext(ctx, q, hash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.APIKey{}, common.ErrNotFound
+		}
+		return entity.APIKey{}, err
+	}
+
+	return key, nil
+}
+
+func (r *APIKeyRepo) List(ctx context.Context) ([]entity.APIKey, error) {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		SELECT id, name, prefix, key_hash, scopes, created_at, revoked_at
+		FROM api_keys
+		ORDER BY created_at, id
+	`
+
+	rows, err := e.QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var res []entity.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, key)
+	}
+
+	return res, rows.Err()
+}
+
+func (r *APIKeyRepo) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		UPDATE api_keys
+		SET revoked_at = $2
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+
+	res, err := e.ExecContext(ctx, q, id, at)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+func scanAPIKey(row rowScanner) (entity.APIKey, error) {
+	var (
+		key    entity.APIKey
+		scopes []string
+	)
+
+	err := row.Scan(
+		&key.ID,
+		&key.Name,
+		&key.Prefix,
+		&key.Hash,
+		pq.Array(&scopes),
+		&key.CreatedAt,
+		&key.RevokedAt,
+	)
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+
+	key.Scopes = make([]entity.Scope, 0, len(scopes))
+	for _, s := range scopes {
+		key.Scopes = append(key.Scopes, entity.Scope(s))
+	}
+
+	return key, nil
+}
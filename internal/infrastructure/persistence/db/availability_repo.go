@@ -0,0 +1,277 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+func (r *UserRepo) CreateUnavailability(ctx context.Context, u entity.Unavailability) error {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		INSERT INTO user_unavailability (id, user_id, starts_at, ends_at, reason)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := e.ExecContext(ctx, q, u.ID, u.UserID, u.StartsAt, u.EndsAt, u.Reason)
+	return err
+}
+
+func (r *UserRepo) GetUnavailability(ctx context.Context, id uuid.UUID) (entity.Unavailability, error) {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		SELECT id, user_id, starts_at, ends_at, reason, handled_at
+		FROM user_unavailability
+		WHERE id = $1
+	`
+
+	var u entity.Unavailability
+	err := e.QueryRowContext(ctx, q, id).Scan(
+		&u.ID,
+		&u.UserID,
+		&u.StartsAt,
+		&u.EndsAt,
+		&u.Reason,
+		&u.HandledAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.Unavailability{}, common.ErrNotFound
+		}
+		return entity.Unavailability{}, err
+	}
+
+	return u, nil
+}
+
+func (r *UserRepo) UpdateUnavailability(ctx context.Context, u entity.Unavailability) error {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		UPDATE user_unavailability
+		SET starts_at = $2,
+			ends_at = $3,
+			reason = $4,
+			handled_at = $5
+		WHERE id = $1
+	`
+
+	res, err := e.ExecContext(ctx, q, u.ID, u.StartsAt, u.EndsAt, u.Reason, u.HandledAt)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepo) DeleteUnavailability(ctx context.Context, id uuid.UUID) error {
+	e := r.db.getExec(ctx)
+
+	const q = `DELETE FROM user_unavailability WHERE id = $1`
+
+	res, err := e.ExecContext(ctx, q, id)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepo) ListUnavailability(ctx context.Context, userID uuid.UUID) ([]entity.Unavailability, error) {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		SELECT id, user_id, starts_at, ends_at, reason, handled_at
+		FROM user_unavailability
+		WHERE user_id = $1
+		ORDER BY starts_at
+	`
+
+	return r.queryUnavailability(ctx, e, q, userID)
+}
+
+func (r *UserRepo) ListStartedLeaves(ctx context.Context, at time.Time) ([]entity.Unavailability, error) {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		SELECT ua.id, ua.user_id, ua.starts_at, ua.ends_at, ua.reason, ua.handled_at
+		FROM user_unavailability ua
+		JOIN users u          ON u.id = ua.user_id
+		JOIN team_settings ts ON ts.team_name = u.team_name
+		WHERE ua.handled_at IS NULL
+		  AND ua.starts_at <= $1
+		  AND ua.ends_at > $1
+		  AND ts.reassign_on_leave = TRUE
+		ORDER BY ua.starts_at
+	`
+
+	return r.queryUnavailability(ctx, e, q, at)
+}
+
+func (r *UserRepo) queryUnavailability(ctx context.Context, e execer, q string, args ...any) ([]entity.Unavailability, error) {
+	rows, err := e.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var res []entity.Unavailability
+
+	for rows.Next() {
+		var u entity.Unavailability
+		if err := rows.Scan(
+			&u.ID,
+			&u.UserID,
+			&u.StartsAt,
+			&u.EndsAt,
+			&u.Reason,
+			&u.HandledAt,
+		); err != nil {
+			return nil, err
+		}
+		res = append(res, u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *UserRepo) ListUnavailableUserIDs(ctx context.Context, teamName string, at time.Time) ([]uuid.UUID, error) {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		SELECT DISTINCT ua.user_id
+		FROM user_unavailability ua
+		JOIN users u ON u.id = ua.user_id
+		WHERE u.team_name = $1
+		  AND ua.starts_at <= $2
+		  AND ua.ends_at > $2
+	`
+
+	rows, err := e.QueryContext(ctx, q, teamName, at)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var res []uuid.UUID
+
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		res = append(res, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *UserRepo) MarkUnavailabilityHandled(ctx context.Context, id uuid.UUID, at time.Time) error {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		UPDATE user_unavailability
+		SET handled_at = $2
+		WHERE id = $1
+	`
+
+	_, err := e.ExecContext(ctx, q, id, at)
+	return err
+}
+
+func (r *UserRepo) ListTeamUnavailability(ctx context.Context, teamName string, from, to time.Time) ([]entity.Unavailability, error) {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		SELECT ua.id, ua.user_id, ua.starts_at, ua.ends_at, ua.reason, ua.handled_at
+		FROM user_unavailability ua
+		JOIN users u ON u.id = ua.user_id
+		WHERE u.team_name = $1
+		  AND ua.starts_at < $3
+		  AND ua.ends_at > $2
+		ORDER BY ua.user_id, ua.starts_at
+	`
+
+	return r.queryUnavailability(ctx, e, q, teamName, from, to)
+}
+
+func (r *UserRepo) ListActivityChanges(ctx context.Context, teamName string, before time.Time) ([]entity.ActivityChange, error) {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		SELECT user_id, is_active, joined, changed_at
+		FROM (
+			(
+				SELECT c.id, c.user_id, c.is_active, c.joined, c.changed_at
+				FROM user_activity_changes c
+				JOIN users u ON u.id = c.user_id
+				WHERE u.team_name = $1
+				  AND c.changed_at < $2
+			)
+			UNION ALL
+			(
+				SELECT DISTINCT ON (c.user_id) c.id, c.user_id, c.is_active, c.joined, c.changed_at
+				FROM user_activity_changes c
+				JOIN users u ON u.id = c.user_id
+				WHERE u.team_name = $1
+				  AND c.changed_at >= $2
+				  AND NOT c.joined
+				ORDER BY c.user_id, c.changed_at, c.id
+			)
+		) c
+		ORDER BY user_id, changed_at, id
+	`
+
+	rows, err := e.QueryContext(ctx, q, teamName, before)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var res []entity.ActivityChange
+
+	for rows.Next() {
+		var c entity.ActivityChange
+		if err := rows.Scan(&c.UserID, &c.IsActive, &c.Joined, &c.ChangedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,154 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+type excludedRow struct {
+	UserID uuid.UUID `json:"user_id"`
+	Reason string    `json:"reason"`
+}
+
+type scoreRow struct {
+	RulesMet       int  `json:"rules_met"`
+	TagsCovered    int  `json:"tags_covered"`
+	InWorkingHours bool `json:"in_working_hours"`
+	CodeOwnerRank  int  `json:"code_owner_rank"`
+	OpenReviews    int  `json:"open_reviews"`
+	PoolOrder      int  `json:"pool_order"`
+}
+
+type chosenRow struct {
+	UserID  uuid.UUID `json:"user_id"`
+	Reasons []string  `json:"reasons"`
+	Score   scoreRow  `json:"score"`
+}
+
+func (r *PRRepo) CreateDecision(ctx context.Context, d entity.AssignmentDecision) error {
+	e := r.db.getExec(ctx)
+
+	excluded := make([]excludedRow, 0, len(d.Excluded))
+	for _, x := range d.Excluded {
+		excluded = append(excluded, excludedRow{UserID: x.UserID, Reason: x.Reason})
+	}
+	chosen := make([]chosenRow, 0, len(d.Chosen))
+	for _, c := range d.Chosen {
+		chosen = append(chosen, chosenRow{UserID: c.UserID, Reasons: c.Reasons, Score: scoreRow(c.Score)})
+	}
+
+	excludedJSON, err := json.Marshal(excluded)
+	if err != nil {
+		return err
+	}
+	chosenJSON, err := json.Marshal(chosen)
+	if err != nil {
+		return err
+	}
+
+	pool := make([]string, 0, len(d.Pool))
+	for _, id := range d.Pool {
+		pool = append(pool, id.String())
+	}
+
+	replaced := uuid.NullUUID{UUID: d.ReplacedUserID, Valid: d.ReplacedUserID != uuid.Nil}
+
+	const q = `
+		INSERT INTO assignment_decisions (id, pr_id, kind, strategy, replaced_user_id, pool, excluded, chosen, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err = e.ExecContext(ctx, q,
+		d.ID,
+		d.PRID,
+		d.Kind,
+		d.Strategy,
+		replaced,
+		pq.Array(pool),
+		excludedJSON,
+		chosenJSON,
+		d.CreatedAt,
+	)
+	return err
+}
+
+func (r *PRRepo) ListDecisions(ctx context.Context, prID uuid.UUID) ([]entity.AssignmentDecision, error) {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		SELECT id, pr_id, kind, strategy, replaced_user_id, pool, excluded, chosen, created_at
+		FROM assignment_decisions
+		WHERE pr_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := e.QueryContext(ctx, q, prID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var res []entity.AssignmentDecision
+
+	for rows.Next() {
+		var (
+			d                        entity.AssignmentDecision
+			replaced                 uuid.NullUUID
+			pool                     []string
+			excludedJSON, chosenJSON []byte
+		)
+
+		if err := rows.Scan(
+			&d.ID,
+			&d.PRID,
+			&d.Kind,
+			&d.Strategy,
+			&replaced,
+			pq.Array(&pool),
+			&excludedJSON,
+			&chosenJSON,
+			&d.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		d.ReplacedUserID = replaced.UUID
+
+		for _, s := range pool {
+			id, err := uuid.Parse(s)
+			if err != nil {
+				return nil, err
+			}
+			d.Pool = append(d.Pool, id)
+		}
+
+		var excluded []excludedRow
+		if err := json.Unmarshal(excludedJSON, &excluded); err != nil {
+			return nil, err
+		}
+		for _, x := range excluded {
+			d.Excluded = append(d.Excluded, entity.ExcludedCandidate{UserID: x.UserID, Reason: x.Reason})
+		}
+
+		var chosen []chosenRow
+		if err := json.Unmarshal(chosenJSON, &chosen); err != nil {
+			return nil, err
+		}
+		for _, c := range chosen {
+			d.Chosen = append(d.Chosen, entity.ReviewerChoice{UserID: c.UserID, Reasons: c.Reasons, Score: entity.ChoiceScore(c.Score)})
+		}
+
+		res = append(res, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
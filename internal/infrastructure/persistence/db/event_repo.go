@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+func (r *PRRepo) CreateEvent(ctx context.Context, ev entity.PREvent) error {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		INSERT INTO pr_events (id, pr_id, kind, reviewer_id, new_reviewer_id, detail, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := e.ExecContext(ctx, q,
+		ev.ID,
+		ev.PRID,
+		ev.Kind,
+		uuid.NullUUID{UUID: ev.ReviewerID, Valid: ev.ReviewerID != uuid.Nil},
+		uuid.NullUUID{UUID: ev.NewReviewerID, Valid: ev.NewReviewerID != uuid.Nil},
+		ev.Detail,
+		ev.CreatedAt,
+	)
+	return err
+}
+
+func (r *PRRepo) ListEvents(ctx context.Context, prID uuid.UUID) ([]entity.PREvent, error) {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		SELECT id, pr_id, kind, reviewer_id, new_reviewer_id, detail, created_at
+		FROM pr_events
+		WHERE pr_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := e.QueryContext(ctx, q, prID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var res []entity.PREvent
+
+	for rows.Next() {
+		var (
+			ev                    entity.PREvent
+			reviewer, newReviewer uuid.NullUUID
+		)
+
+		if err := rows.Scan(
+			&ev.ID,
+			&ev.PRID,
+			&ev.Kind,
+			&reviewer,
+			&newReviewer,
+			&ev.Detail,
+			&ev.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		ev.ReviewerID = reviewer.UUID
+		ev.NewReviewerID = newReviewer.UUID
+
+		res = append(res, ev)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
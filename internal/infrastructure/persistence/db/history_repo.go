@@ -0,0 +1,246 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+func (r *PRRepo) ListTeamPRTimes(ctx context.Context, teamName string, from, to time.Time) ([]entity.PRTimes, error) {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		SELECT p.id, p.created_at, p.merged_at
+		FROM pull_requests p
+		JOIN users u ON u.id = p.author_id
+		WHERE u.team_name = $1
+		  AND ((p.created_at >= $2 AND p.created_at < $3)
+		    OR (p.merged_at >= $2 AND p.merged_at < $3))
+		ORDER BY p.created_at, p.id
+	`
+
+	rows, err := e.QueryContext(ctx, q, teamName, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var res []entity.PRTimes
+
+	for rows.Next() {
+		var t entity.PRTimes
+		if err := rows.Scan(&t.PRID, &t.CreatedAt, &t.MergedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *PRRepo) ListFirstReviews(ctx context.Context, teamName string, from, to time.Time) ([]entity.FirstReview, error) {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		WITH first_reviews AS (
+			SELECT ev.pr_id, ev.reviewer_id, MIN(ev.created_at) AS reviewed_at
+			FROM pr_events ev
+			WHERE ev.kind = 'reviewed'
+			GROUP BY ev.pr_id, ev.reviewer_id
+		)
+		SELECT u.id, u.name, fr.pr_id, a.assigned_at, fr.reviewed_at
+		FROM first_reviews fr
+		JOIN users u ON u.id = fr.reviewer_id
+		CROSS JOIN LATERAL (
+			SELECT MAX(x.at) AS assigned_at
+			FROM (
+				SELECT d.created_at AS at
+				FROM assignment_decisions d
+				WHERE d.pr_id = fr.pr_id
+				  AND d.chosen @> jsonb_build_array(jsonb_build_object('user_id', fr.reviewer_id::text))
+				UNION ALL
+				SELECT ev.created_at
+				FROM pr_events ev
+				WHERE ev.pr_id = fr.pr_id
+				  AND ev.kind = 'escalation_lead_added'
+				  AND ev.new_reviewer_id = fr.reviewer_id
+				UNION ALL
+				SELECT prr.assigned_at
+				FROM pr_reviewers prr
+				WHERE prr.pr_id = fr.pr_id AND prr.reviewer_id = fr.reviewer_id
+			) x
+			WHERE x.at <= fr.reviewed_at
+		) a
+		WHERE u.team_name = $1
+		  AND fr.reviewed_at >= $2 AND fr.reviewed_at < $3
+		  AND a.assigned_at IS NOT NULL
+		ORDER BY u.name, u.id, fr.reviewed_at
+	`
+
+	rows, err := e.QueryContext(ctx, q, teamName, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var res []entity.FirstReview
+
+	for rows.Next() {
+		var f entity.FirstReview
+		if err := rows.Scan(&f.ReviewerID, &f.Username, &f.PRID, &f.AssignedAt, &f.ReviewedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, f)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *PRRepo) CountAssignments(ctx context.Context, teamName string, from, to time.Time) (map[uuid.UUID]int, error) {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		SELECT a.user_id, COUNT(*)
+		FROM (
+			SELECT (c->>'user_id')::uuid AS user_id
+			FROM assignment_decisions d
+			CROSS JOIN LATERAL jsonb_array_elements(d.chosen) AS c
+			WHERE d.created_at >= $2 AND d.created_at < $3
+			UNION ALL
+			SELECT ev.new_reviewer_id
+			FROM pr_events ev
+			WHERE ev.kind = 'escalation_lead_added'
+			  AND ev.created_at >= $2 AND ev.created_at < $3
+		) a
+		JOIN users u ON u.id = a.user_id
+		WHERE u.team_name = $1
+		GROUP BY a.user_id
+	`
+
+	rows, err := e.QueryContext(ctx, q, teamName, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	res := make(map[uuid.UUID]int)
+
+	for rows.Next() {
+		var (
+			id uuid.UUID
+			n  int
+		)
+		if err := rows.Scan(&id, &n); err != nil {
+			return nil, err
+		}
+		res[id] = n
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *PRRepo) GetReviewTotals(ctx context.Context, userID uuid.UUID) (entity.ReviewTotals, error) {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		SELECT
+			COUNT(*) FILTER (WHERE p.status = 'OPEN') AS open_assignments,
+			COUNT(p.id)                              AS current_assignments,
+			(SELECT COUNT(*)
+			 FROM assignment_decisions d
+			 WHERE d.kind = 'reassign' AND d.replaced_user_id = $1) AS reassigned_away,
+			EXTRACT(EPOCH FROM AVG(p.merged_at - p.created_at) FILTER (WHERE p.status = 'MERGED')) AS avg_open_seconds
+		FROM pr_reviewers prr
+		JOIN pull_requests p ON p.id = prr.pr_id
+		WHERE prr.reviewer_id = $1
+	`
+
+	var (
+		t       entity.ReviewTotals
+		current int
+		avg     sql.NullFloat64
+	)
+	if err := e.QueryRowContext(ctx, q, userID).Scan(&t.OpenAssignments, &current, &t.ReassignedAway, &avg); err != nil {
+		return entity.ReviewTotals{}, err
+	}
+
+	t.TotalAssignments = current + t.ReassignedAway
+	if avg.Valid {
+		d := time.Duration(avg.Float64 * float64(time.Second))
+		t.AvgOpenTime = &d
+	}
+
+	return t, nil
+}
+
+func (r *PRRepo) GetAuthorTotals(ctx context.Context, userID uuid.UUID) (entity.AuthorTotals, error) {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE status = 'MERGED')
+		FROM pull_requests
+		WHERE author_id = $1
+	`
+
+	var t entity.AuthorTotals
+	if err := e.QueryRowContext(ctx, q, userID).Scan(&t.Authored, &t.Merged); err != nil {
+		return entity.AuthorTotals{}, err
+	}
+
+	return t, nil
+}
+
+func (r *PRRepo) CountOpenByTeam(ctx context.Context) (map[string]int, error) {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		SELECT t.name, COUNT(p.id)
+		FROM teams t
+		LEFT JOIN users u         ON u.team_name = t.name
+		LEFT JOIN pull_requests p ON p.author_id = u.id AND p.status = 'OPEN'
+		GROUP BY t.name
+	`
+
+	rows, err := e.QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	res := make(map[string]int)
+
+	for rows.Next() {
+		var (
+			team string
+			n    int
+		)
+		if err := rows.Scan(&team, &n); err != nil {
+			return nil, err
+		}
+		res[team] = n
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,128 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+type IdempotencyRepo struct {
+	db *DB
+}
+
+func NewIdempotencyRepo(db *DB) *IdempotencyRepo {
+	return &IdempotencyRepo{db: db}
+}
+
+func (r *IdempotencyRepo) Reserve(ctx context.Context, rec entity.IdempotencyRecord) (entity.IdempotencyRecord, bool, error) {
+	e := r.db.getExec(ctx)
+
+	const qExpired = `DELETE FROM idempotency_keys WHERE client = $1 AND key = $2 AND expires_at <= $3`
+
+	if _, err := e.ExecContext(ctx, qExpired, rec.Client, rec.Key, rec.CreatedAt); err != nil {
+		return entity.IdempotencyRecord{}, false, err
+	}
+
+	// The conflicting row may be deleted, by the cleanup job or a released request, before it is
+	// read back; the key is then free and the insert is tried once more.
+	for attempt := 0; attempt < 2; attempt++ {
+		stored, reserved, err := r.insertOrGet(ctx, e, rec)
+		if !errors.Is(err, sql.ErrNoRows) {
+			return stored, reserved, err
+		}
+	}
+
+	return entity.IdempotencyRecord{}, false, common.ErrIdempotencyPending
+}
+
+// insertOrGet claims the key, or returns the row holding it; sql.ErrNoRows if that row is gone.
+func (r *IdempotencyRepo) insertOrGet(ctx context.Context, e execer, rec entity.IdempotencyRecord) (entity.IdempotencyRecord, bool, error) {
+	const qIns = `
+		INSERT INTO idempotency_keys (client, key, request_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (client, key) DO NOTHING
+	`
+
+	res, err := e.ExecContext(ctx, qIns, rec.Client, rec.Key, rec.RequestHash, rec.CreatedAt, rec.ExpiresAt)
+	if err != nil {
+		return entity.IdempotencyRecord{}, false, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return entity.IdempotencyRecord{}, false, err
+	}
+	if n == 1 {
+		return rec, true, nil
+	}
+
+	const qSel = `
+		SELECT client, key, request_hash, status_code, content_type, etag, response_body, created_at, expires_at
+		FROM idempotency_keys
+		WHERE client = $1 AND key = $2
+	`
+
+	var (
+		stored entity.IdempotencyRecord
+		status sql.NullInt64
+	)
+	err = e.QueryRowContext(ctx, qSel, rec.Client, rec.Key).Scan(
+		&stored.Client,
+		&stored.Key,
+		&stored.RequestHash,
+		&status,
+		&stored.ContentType,
+		&stored.ETag,
+		&stored.Body,
+		&stored.CreatedAt,
+		&stored.ExpiresAt,
+	)
+	if err != nil {
+		return entity.IdempotencyRecord{}, false, err
+	}
+	stored.StatusCode = int(status.Int64)
+
+	return stored, false, nil
+}
+
+func (r *IdempotencyRepo) Complete(ctx context.Context, rec entity.IdempotencyRecord) error {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		UPDATE idempotency_keys
+		SET status_code = $3,
+			content_type = $4,
+			etag = $5,
+			response_body = $6
+		WHERE client = $1 AND key = $2
+	`
+
+	_, err := e.ExecContext(ctx, q, rec.Client, rec.Key, rec.StatusCode, rec.ContentType, rec.ETag, rec.Body)
+	return err
+}
+
+func (r *IdempotencyRepo) Delete(ctx context.Context, client, key string) error {
+	e := r.db.getExec(ctx)
+
+	const q = `DELETE FROM idempotency_keys WHERE client = $1 AND key = $2`
+
+	_, err := e.ExecContext(ctx, q, client, key)
+	return err
+}
+
+func (r *IdempotencyRepo) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	e := r.db.getExec(ctx)
+
+	const q = `DELETE FROM idempotency_keys WHERE expires_at <= $1`
+
+	res, err := e.ExecContext(ctx, q, before)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
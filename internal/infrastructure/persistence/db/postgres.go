@@ -3,11 +3,23 @@ package db
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"log/slog"
+	"math/rand/v2"
 	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"github.com/pressly/goose/v3"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/app"
+)
+
+const (
+	txRetryBaseDelay = 10 * time.Millisecond
+	txRetryMaxDelay  = 500 * time.Millisecond
 )
 
 type Config struct {
@@ -17,10 +29,22 @@ type Config struct {
 	MaxOpenConns    int
 	MaxIdleConns    int
 	ConnMaxLifetime time.Duration
+
+	// TxMaxAttempts bounds how many times InTx runs a transaction that hits serialization
+	// failures or deadlocks; it defaults to 5.
+	TxMaxAttempts int
+
+	// Logger defaults to slog.Default().
+	Logger *slog.Logger
 }
 
 type DB struct {
 	sql *sql.DB
+	log *slog.Logger
+	// txMaxAttempts is Config.TxMaxAttempts.
+	txMaxAttempts int
+	// schemaVersion is the latest migration found in MigrationsDir at startup.
+	schemaVersion int64
 }
 
 func New(ctx context.Context, cfg Config) (*DB, error) {
@@ -33,6 +57,12 @@ func New(ctx context.Context, cfg Config) (*DB, error) {
 	if cfg.ConnMaxLifetime <= 0 {
 		cfg.ConnMaxLifetime = time.Hour
 	}
+	if cfg.TxMaxAttempts <= 0 {
+		cfg.TxMaxAttempts = 5
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
 
 	db, err := sql.Open("postgres", cfg.DSN)
 	if err != nil {
@@ -56,17 +86,85 @@ func New(ctx context.Context, cfg Config) (*DB, error) {
 		return nil, err
 	}
 
-	return &DB{sql: db}, nil
+	migrations, err := goose.CollectMigrations(cfg.MigrationsDir, 0, goose.MaxVersion)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	var schemaVersion int64
+	if last, err := migrations.Last(); err == nil {
+		schemaVersion = last.Version
+	}
+
+	return &DB{
+		sql:           db,
+		log:           cfg.Logger,
+		txMaxAttempts: cfg.TxMaxAttempts,
+		schemaVersion: schemaVersion,
+	}, nil
 }
 
 func (db *DB) Close() error {
 	return db.sql.Close()
 }
 
+func (db *DB) Stats() sql.DBStats {
+	return db.sql.Stats()
+}
+
+func (db *DB) Ping(ctx context.Context) error {
+	return db.sql.PingContext(ctx)
+}
+
+// CheckMigrations fails unless the database schema is at the latest migration shipped with the service.
+func (db *DB) CheckMigrations(ctx context.Context) error {
+	current, err := goose.GetDBVersionContext(ctx, db.sql)
+	if err != nil {
+		return err
+	}
+	if current != db.schemaVersion {
+		return fmt.Errorf("schema at version %d, expected %d", current, db.schemaVersion)
+	}
+	return nil
+}
+
 type txKey struct{}
 
-func (db *DB) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
-	tx, err := db.sql.BeginTx(ctx, nil)
+// InTx runs fn in a transaction. A transaction failing with a serialization failure or a deadlock
+// is rolled back and run again after a jittered backoff, up to txMaxAttempts times.
+func (db *DB) InTx(ctx context.Context, opts app.TxOptions, fn func(ctx context.Context) error) error {
+	ctx, span := tracer.Start(ctx, "db.tx", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	txOpts := &sql.TxOptions{
+		Isolation: isolationLevel(opts.Isolation),
+		ReadOnly:  opts.ReadOnly,
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := db.runTx(ctx, txOpts, fn)
+		if err == nil {
+			span.SetAttributes(attribute.Int("db.tx.attempts", attempt))
+			return nil
+		}
+		if !isRetryableTxErr(err) || attempt >= db.txMaxAttempts {
+			span.SetAttributes(attribute.Int("db.tx.attempts", attempt))
+			return recordErr(span, err)
+		}
+
+		delay := txRetryDelay(attempt)
+		db.log.DebugContext(ctx, "retrying transaction", "attempt", attempt, "delay", delay, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return recordErr(span, ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (db *DB) runTx(ctx context.Context, opts *sql.TxOptions, fn func(ctx context.Context) error) error {
+	tx, err := db.sql.BeginTx(ctx, opts)
 	if err != nil {
 		return err
 	}
@@ -74,17 +172,47 @@ func (db *DB) InTx(ctx context.Context, fn func(ctx context.Context) error) erro
 	ctxTx := context.WithValue(ctx, txKey{}, tx)
 
 	if err := fn(ctxTx); err != nil {
+		trace.SpanFromContext(ctx).SetAttributes(attribute.Bool("db.tx.rolled_back", true))
 		if rbErr := tx.Rollback(); rbErr != nil {
 			return fmt.Errorf("rollback error: %v, original error: %w", rbErr, err)
 		}
 		return err
 	}
 
-	if err := tx.Commit(); err != nil {
-		return err
+	return tx.Commit()
+}
+
+func isolationLevel(l app.IsolationLevel) sql.IsolationLevel {
+	switch l {
+	case app.IsolationReadCommitted:
+		return sql.LevelReadCommitted
+	case app.IsolationRepeatableRead:
+		return sql.LevelRepeatableRead
+	case app.IsolationSerializable:
+		return sql.LevelSerializable
+	default:
+		return sql.LevelDefault
 	}
+}
 
-	return nil
+// isRetryableTxErr reports serialization failures (40001) and deadlocks (40P01), after which
+// Postgres expects the whole transaction to be retried.
+func isRetryableTxErr(err error) bool {
+	var pgErr *pq.Error
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == "40001" || pgErr.Code == "40P01"
+}
+
+// txRetryDelay doubles with every attempt up to txRetryMaxDelay; a random half of it is jitter
+// so that conflicting transactions do not retry in lockstep.
+func txRetryDelay(attempt int) time.Duration {
+	d := txRetryBaseDelay << (attempt - 1)
+	if d <= 0 || d > txRetryMaxDelay {
+		d = txRetryMaxDelay
+	}
+	return d/2 + rand.N(d/2+1)
 }
 
 type execer interface {
@@ -95,7 +223,14 @@ type execer interface {
 
 func (db *DB) getExec(ctx context.Context) execer {
 	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok && tx != nil {
-		return tx
+		return tracedExecer{tx}
+	}
+	return tracedExecer{db.sql}
+}
+
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
 	}
-	return db.sql
+	return s
 }
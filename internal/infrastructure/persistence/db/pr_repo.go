@@ -3,7 +3,7 @@ package db
 import (
 	"context"
 	"database/sql"
-	"log"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
@@ -24,8 +24,8 @@ func (r *PRRepo) Create(ctx context.Context, pr entity.PR) error {
 	e := r.db.getExec(ctx)
 
 	const qPR = `
-		INSERT INTO pull_requests (id, title, author_id, status, created_at, merged_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO pull_requests (id, title, author_id, status, created_at, merged_at, changed_files, required_tags, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
 	_, err := e.ExecContext(ctx, qPR,
@@ -35,6 +35,9 @@ func (r *PRRepo) Create(ctx context.Context, pr entity.PR) error {
 		string(pr.Status),
 		pr.CreatedAt,
 		pr.MergedAt,
+		pq.Array(nonNilStrings(pr.ChangedFiles)),
+		pq.Array(nonNilStrings(pr.RequiredTags)),
+		pr.Version,
 	)
 	if err != nil {
 		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == "23505" {
@@ -48,12 +51,12 @@ func (r *PRRepo) Create(ctx context.Context, pr entity.PR) error {
 	}
 
 	const insertReviewer = `
-		INSERT INTO pr_reviewers (pr_id, reviewer_id)
-		VALUES ($1, $2)
+		INSERT INTO pr_reviewers (pr_id, reviewer_id, assigned_at)
+		VALUES ($1, $2, $3)
 	`
 
 	for _, reviewerID := range pr.Reviewers {
-		if _, err := e.ExecContext(ctx, insertReviewer, pr.ID, reviewerID); err != nil {
+		if _, err := e.ExecContext(ctx, insertReviewer, pr.ID, reviewerID, pr.ReviewerAssignedAt(reviewerID)); err != nil {
 			return err
 		}
 	}
@@ -62,13 +65,24 @@ func (r *PRRepo) Create(ctx context.Context, pr entity.PR) error {
 }
 
 func (r *PRRepo) GetByID(ctx context.Context, id uuid.UUID) (entity.PR, error) {
+	return r.getByID(ctx, id, false)
+}
+
+func (r *PRRepo) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (entity.PR, error) {
+	return r.getByID(ctx, id, true)
+}
+
+func (r *PRRepo) getByID(ctx context.Context, id uuid.UUID, forUpdate bool) (entity.PR, error) {
 	q := r.db.getExec(ctx)
 
-	const qPR = `
-		SELECT id, title, author_id, status, created_at, merged_at
+	qPR := `
+		SELECT id, title, author_id, status, created_at, merged_at, changed_files, required_tags, version
 		FROM pull_requests
 		WHERE id = $1
 	`
+	if forUpdate {
+		qPR += ` FOR UPDATE`
+	}
 
 	var pr entity.PR
 	var status string
@@ -80,6 +94,9 @@ func (r *PRRepo) GetByID(ctx context.Context, id uuid.UUID) (entity.PR, error) {
 		&status,
 		&pr.CreatedAt,
 		&pr.MergedAt,
+		pq.Array(&pr.ChangedFiles),
+		pq.Array(&pr.RequiredTags),
+		&pr.Version,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -90,11 +107,10 @@ func (r *PRRepo) GetByID(ctx context.Context, id uuid.UUID) (entity.PR, error) {
 
 	pr.Status = entity.PRStatus(status)
 
-	reviewers, err := r.loadReviewers(ctx, id)
+	pr.Reviewers, pr.AssignedAt, err = r.loadReviewers(ctx, id)
 	if err != nil {
 		return entity.PR{}, err
 	}
-	pr.Reviewers = reviewers
 
 	return pr, nil
 }
@@ -107,8 +123,9 @@ func (r *PRRepo) Update(ctx context.Context, pr entity.PR) error {
 		SET title = $2,
 			author_id = $3,
 			status = $4,
-			merged_at = $5
-		WHERE id = $1
+			merged_at = $5,
+			version = version + 1
+		WHERE id = $1 AND version = $6
 	`
 
 	res, err := e.ExecContext(ctx, qPR,
@@ -117,6 +134,7 @@ func (r *PRRepo) Update(ctx context.Context, pr entity.PR) error {
 		pr.AuthorID,
 		string(pr.Status),
 		pr.MergedAt,
+		pr.Version,
 	)
 	if err != nil {
 		return err
@@ -127,26 +145,38 @@ func (r *PRRepo) Update(ctx context.Context, pr entity.PR) error {
 		return err
 	}
 	if n == 0 {
+		const qExists = `SELECT EXISTS (SELECT 1 FROM pull_requests WHERE id = $1)`
+
+		var exists bool
+		if err := e.QueryRowContext(ctx, qExists, pr.ID).Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			return common.ErrConflict
+		}
 		return common.ErrNotFound
 	}
 
-	const qDel = `DELETE FROM pr_reviewers WHERE pr_id = $1`
-
-	if _, err := e.ExecContext(ctx, qDel, pr.ID); err != nil {
-		return err
+	// Reviewers staying on the PR keep their rows, so their assigned_at is preserved.
+	ids := make([]string, 0, len(pr.Reviewers))
+	for _, id := range pr.Reviewers {
+		ids = append(ids, id.String())
 	}
 
-	if len(pr.Reviewers) == 0 {
-		return nil
+	const qDel = `DELETE FROM pr_reviewers WHERE pr_id = $1 AND NOT (reviewer_id = ANY($2::uuid[]))`
+
+	if _, err := e.ExecContext(ctx, qDel, pr.ID, pq.Array(ids)); err != nil {
+		return err
 	}
 
 	const qIns = `
-		INSERT INTO pr_reviewers (pr_id, reviewer_id)
-		VALUES ($1, $2)
+		INSERT INTO pr_reviewers (pr_id, reviewer_id, assigned_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (pr_id, reviewer_id) DO NOTHING
 	`
 
 	for _, reviewerID := range pr.Reviewers {
-		if _, err := e.ExecContext(ctx, qIns, pr.ID, reviewerID); err != nil {
+		if _, err := e.ExecContext(ctx, qIns, pr.ID, reviewerID, pr.ReviewerAssignedAt(reviewerID)); err != nil {
 			return err
 		}
 	}
@@ -158,7 +188,7 @@ func (r *PRRepo) ListByReviewerID(ctx context.Context, reviewerID uuid.UUID) ([]
 	q := r.db.getExec(ctx)
 
 	const query = `
-		SELECT pr.id, pr.title, pr.author_id, pr.status, pr.created_at, pr.merged_at
+		SELECT pr.id, pr.title, pr.author_id, pr.status, pr.created_at, pr.merged_at, pr.changed_files, pr.required_tags, pr.version
 		FROM pull_requests pr
 		JOIN pr_reviewers r ON r.pr_id = pr.id
 		WHERE r.reviewer_id = $1
@@ -171,7 +201,7 @@ func (r *PRRepo) ListByReviewerID(ctx context.Context, reviewerID uuid.UUID) ([]
 	}
 	defer func() {
 		if cerr := rows.Close(); cerr != nil {
-			log.Printf("rows close error: %v", cerr)
+			r.db.log.WarnContext(ctx, "rows close error", "error", cerr)
 		}
 	}()
 
@@ -188,17 +218,19 @@ func (r *PRRepo) ListByReviewerID(ctx context.Context, reviewerID uuid.UUID) ([]
 			&status,
 			&pr.CreatedAt,
 			&pr.MergedAt,
+			pq.Array(&pr.ChangedFiles),
+			pq.Array(&pr.RequiredTags),
+			&pr.Version,
 		); err != nil {
 			return nil, err
 		}
 
 		pr.Status = entity.PRStatus(status)
 
-		reviewers, err := r.loadReviewers(ctx, pr.ID)
+		pr.Reviewers, pr.AssignedAt, err = r.loadReviewers(ctx, pr.ID)
 		if err != nil {
 			return nil, err
 		}
-		pr.Reviewers = reviewers
 
 		result = append(result, pr)
 	}
@@ -210,58 +242,110 @@ func (r *PRRepo) ListByReviewerID(ctx context.Context, reviewerID uuid.UUID) ([]
 	return result, nil
 }
 
-func (r *PRRepo) loadReviewers(ctx context.Context, prID uuid.UUID) ([]uuid.UUID, error) {
+func (r *PRRepo) loadReviewers(ctx context.Context, prID uuid.UUID) ([]uuid.UUID, map[uuid.UUID]time.Time, error) {
 	q := r.db.getExec(ctx)
 
 	const query = `
-		SELECT reviewer_id
+		SELECT reviewer_id, assigned_at
 		FROM pr_reviewers
 		WHERE pr_id = $1
+		ORDER BY assigned_at, reviewer_id
 	`
 
 	rows, err := q.QueryContext(ctx, query, prID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer func() {
 		if cerr := rows.Close(); cerr != nil {
-			log.Printf("rows close error: %v", cerr)
+			r.db.log.WarnContext(ctx, "rows close error", "error", cerr)
 		}
 	}()
 
 	var reviewers []uuid.UUID
+	assignedAt := make(map[uuid.UUID]time.Time)
 
 	for rows.Next() {
 		var id uuid.UUID
-		if err := rows.Scan(&id); err != nil {
-			return nil, err
+		var at time.Time
+		if err := rows.Scan(&id, &at); err != nil {
+			return nil, nil, err
 		}
 		reviewers = append(reviewers, id)
+		assignedAt[id] = at
 	}
 
 	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return reviewers, assignedAt, nil
+}
+
+func (r *PRRepo) ListOpenAssignments(ctx context.Context, teamName string, assignedBefore time.Time) ([]entity.ReviewAssignment, error) {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		SELECT p.id, p.title, p.author_id, prr.reviewer_id, prr.assigned_at
+		FROM pr_reviewers prr
+		JOIN pull_requests p ON p.id = prr.pr_id
+		JOIN users u         ON u.id = prr.reviewer_id
+		WHERE p.status = 'OPEN'
+		  AND u.team_name = $1
+		  AND prr.assigned_at <= $2
+		  AND NOT EXISTS (
+			SELECT 1
+			FROM pr_events ev
+			WHERE ev.pr_id = prr.pr_id
+			  AND ev.reviewer_id = prr.reviewer_id
+			  AND ev.kind = 'reviewed'
+			  AND ev.created_at >= prr.assigned_at
+		  )
+		ORDER BY prr.assigned_at, p.id
+	`
+
+	rows, err := e.QueryContext(ctx, q, teamName, assignedBefore)
+	if err != nil {
 		return nil, err
 	}
+	defer func() {
+		_ = rows.Close()
+	}()
 
-	return reviewers, nil
+	var res []entity.ReviewAssignment
+
+	for rows.Next() {
+		var a entity.ReviewAssignment
+		if err := rows.Scan(
+			&a.PRID,
+			&a.Title,
+			&a.AuthorID,
+			&a.ReviewerID,
+			&a.AssignedAt,
+		); err != nil {
+			return nil, err
+		}
+		res = append(res, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
 }
 
-func (r *PRRepo) ListReviewerStats(ctx context.Context, teamName string) ([]entity.ReviewerStats, error) {
+func (r *PRRepo) CountOpenReviews(ctx context.Context, teamName string) (map[uuid.UUID]int, error) {
 	e := r.db.getExec(ctx)
 
 	const q = `
-		SELECT
-			u.id,
-			u.name,
-			u.team_name,
-			COUNT(*) AS assigned_open_prs
+		SELECT prr.reviewer_id, COUNT(*)
 		FROM pr_reviewers prr
 		JOIN pull_requests p ON p.id = prr.pr_id
 		JOIN users u         ON u.id = prr.reviewer_id
 		WHERE p.status = 'OPEN'
 		  AND u.team_name = $1
-		GROUP BY u.id, u.name, u.team_name
-		ORDER BY assigned_open_prs DESC
+		GROUP BY prr.reviewer_id
 	`
 
 	rows, err := e.QueryContext(ctx, q, teamName)
@@ -272,6 +356,66 @@ func (r *PRRepo) ListReviewerStats(ctx context.Context, teamName string) ([]enti
 		_ = rows.Close()
 	}()
 
+	res := make(map[uuid.UUID]int)
+
+	for rows.Next() {
+		var (
+			id uuid.UUID
+			n  int
+		)
+		if err := rows.Scan(&id, &n); err != nil {
+			return nil, err
+		}
+		res[id] = n
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *PRRepo) ListReviewerStats(ctx context.Context, teamName string, includeInactive bool) ([]entity.ReviewerStats, error) {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		SELECT
+			u.id,
+			u.name,
+			u.team_name,
+			u.is_active,
+			COUNT(*) FILTER (WHERE p.status = 'OPEN')   AS assigned_open_prs,
+			COUNT(p.id) + (SELECT COUNT(*)
+			               FROM assignment_decisions d
+			               WHERE d.kind = 'reassign' AND d.replaced_user_id = u.id) AS total_reviews,
+			COUNT(*) FILTER (WHERE p.status = 'MERGED') AS merged_reviews,
+			GREATEST(
+				MAX(prr.assigned_at),
+				(SELECT MAX(d.created_at)
+				 FROM assignment_decisions d
+				 WHERE d.chosen @> jsonb_build_array(jsonb_build_object('user_id', u.id::text))),
+				(SELECT MAX(ev.created_at)
+				 FROM pr_events ev
+				 WHERE ev.kind = 'escalation_lead_added' AND ev.new_reviewer_id = u.id)
+			) AS last_assigned_at
+		FROM users u
+		LEFT JOIN pr_reviewers prr  ON prr.reviewer_id = u.id
+		LEFT JOIN pull_requests p   ON p.id = prr.pr_id
+		WHERE u.team_name = $1
+		  AND (u.is_active OR $2)
+		GROUP BY u.id, u.name, u.team_name, u.is_active
+		ORDER BY assigned_open_prs DESC, u.name, u.id
+	`
+
+	rows, err := e.QueryContext(ctx, q, teamName, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
 	var res []entity.ReviewerStats
 
 	for rows.Next() {
@@ -280,7 +424,11 @@ func (r *PRRepo) ListReviewerStats(ctx context.Context, teamName string) ([]enti
 			&s.UserID,
 			&s.Username,
 			&s.TeamName,
+			&s.IsActive,
 			&s.AssignedOpenPRs,
+			&s.TotalReviews,
+			&s.MergedReviews,
+			&s.LastAssignedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -5,17 +5,21 @@ import (
 )
 
 type Repositories struct {
-	Teams app.TeamRepo
-	Users app.UserRepo
-	PRs   app.PRRepo
-	Tx    app.TxManager
+	Teams       app.TeamRepo
+	Users       app.UserRepo
+	PRs         app.PRRepo
+	APIKeys     app.APIKeyRepo
+	Idempotency app.IdempotencyRepo
+	Tx          app.TxManager
 }
 
 func NewRepositories(db *DB) Repositories {
 	return Repositories{
-		Teams: NewTeamRepo(db),
-		Users: NewUserRepo(db),
-		PRs:   NewPRRepo(db),
-		Tx:    db,
+		Teams:       NewTeamRepo(db),
+		Users:       NewUserRepo(db),
+		PRs:         NewPRRepo(db),
+		APIKeys:     NewAPIKeyRepo(db),
+		Idempotency: NewIdempotencyRepo(db),
+		Tx:          db,
 	}
 }
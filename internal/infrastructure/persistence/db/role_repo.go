@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+func (r *TeamRepo) GetRole(ctx context.Context, teamName string, userID uuid.UUID) (entity.TeamRole, error) {
+	e := r.db.getExec(ctx)
+
+	const q = `SELECT role FROM team_roles WHERE team_name = $1 AND user_id = $2`
+
+	var role entity.TeamRole
+	if err := e.QueryRowContext(ctx, q, teamName, userID).Scan(&role); err != nil {
+		if err == sql.ErrNoRows {
+			return entity.RoleNone, common.ErrNotFound
+		}
+		return entity.RoleNone, err
+	}
+
+	return role, nil
+}
+
+func (r *TeamRepo) SetRole(ctx context.Context, role entity.TeamMemberRole) error {
+	e := r.db.getExec(ctx)
+
+	if role.Role == entity.RoleMember {
+		const qDel = `DELETE FROM team_roles WHERE team_name = $1 AND user_id = $2`
+		_, err := e.ExecContext(ctx, qDel, role.TeamName, role.UserID)
+		return err
+	}
+
+	const q = `
+		INSERT INTO team_roles (team_name, user_id, role)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (team_name, user_id) DO UPDATE
+		SET role = EXCLUDED.role
+	`
+
+	_, err := e.ExecContext(ctx, q, role.TeamName, role.UserID, role.Role)
+	return err
+}
+
+func (r *TeamRepo) ListRoles(ctx context.Context, teamName string) ([]entity.TeamMemberRole, error) {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		SELECT team_name, user_id, role
+		FROM team_roles
+		WHERE team_name = $1
+		ORDER BY role, user_id
+	`
+
+	rows, err := e.QueryContext(ctx, q, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var res []entity.TeamMemberRole
+	for rows.Next() {
+		var role entity.TeamMemberRole
+		if err := rows.Scan(&role.TeamName, &role.UserID, &role.Role); err != nil {
+			return nil, err
+		}
+		res = append(res, role)
+	}
+
+	return res, rows.Err()
+}
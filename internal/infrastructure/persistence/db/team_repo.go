@@ -3,7 +3,9 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/lib/pq"
 
 	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
@@ -57,3 +59,252 @@ func (r *TeamRepo) GetByName(ctx context.Context, name string) (entity.Team, err
 
 	return t, nil
 }
+
+func (r *TeamRepo) GetCodeOwners(ctx context.Context, teamName string) (entity.CodeOwners, error) {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		SELECT pattern, owners
+		FROM code_owner_rules
+		WHERE team_name = $1
+		ORDER BY position
+	`
+
+	rows, err := e.QueryContext(ctx, q, teamName)
+	if err != nil {
+		return entity.CodeOwners{}, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	res := entity.CodeOwners{TeamName: teamName}
+
+	for rows.Next() {
+		var rule entity.CodeOwnerRule
+		var owners []string
+
+		if err := rows.Scan(&rule.Pattern, pq.Array(&owners)); err != nil {
+			return entity.CodeOwners{}, err
+		}
+
+		rule.Owners = make([]uuid.UUID, 0, len(owners))
+		for _, o := range owners {
+			id, err := uuid.Parse(o)
+			if err != nil {
+				return entity.CodeOwners{}, err
+			}
+			rule.Owners = append(rule.Owners, id)
+		}
+
+		res.Rules = append(res.Rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return entity.CodeOwners{}, err
+	}
+
+	return res, nil
+}
+
+func (r *TeamRepo) ReplaceCodeOwners(ctx context.Context, owners entity.CodeOwners) error {
+	e := r.db.getExec(ctx)
+
+	const qDel = `DELETE FROM code_owner_rules WHERE team_name = $1`
+
+	if _, err := e.ExecContext(ctx, qDel, owners.TeamName); err != nil {
+		return err
+	}
+
+	const qIns = `
+		INSERT INTO code_owner_rules (team_name, position, pattern, owners)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	for i, rule := range owners.Rules {
+		ids := make([]string, 0, len(rule.Owners))
+		for _, id := range rule.Owners {
+			ids = append(ids, id.String())
+		}
+
+		if _, err := e.ExecContext(ctx, qIns, owners.TeamName, i, rule.Pattern, pq.Array(ids)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *TeamRepo) GetRules(ctx context.Context, teamName string) (entity.TeamRules, error) {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		SELECT kind, author_id, reviewers
+		FROM team_rules
+		WHERE team_name = $1
+		ORDER BY position
+	`
+
+	rows, err := e.QueryContext(ctx, q, teamName)
+	if err != nil {
+		return entity.TeamRules{}, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	res := entity.TeamRules{TeamName: teamName}
+
+	for rows.Next() {
+		var rule entity.TeamRule
+		var author uuid.NullUUID
+		var reviewers []string
+
+		if err := rows.Scan(&rule.Kind, &author, pq.Array(&reviewers)); err != nil {
+			return entity.TeamRules{}, err
+		}
+
+		rule.AuthorID = author.UUID
+		for _, s := range reviewers {
+			id, err := uuid.Parse(s)
+			if err != nil {
+				return entity.TeamRules{}, err
+			}
+			rule.Reviewers = append(rule.Reviewers, id)
+		}
+
+		res.Rules = append(res.Rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return entity.TeamRules{}, err
+	}
+
+	return res, nil
+}
+
+func (r *TeamRepo) ReplaceRules(ctx context.Context, rules entity.TeamRules) error {
+	e := r.db.getExec(ctx)
+
+	const qDel = `DELETE FROM team_rules WHERE team_name = $1`
+
+	if _, err := e.ExecContext(ctx, qDel, rules.TeamName); err != nil {
+		return err
+	}
+
+	const qIns = `
+		INSERT INTO team_rules (team_name, position, kind, author_id, reviewers)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	for i, rule := range rules.Rules {
+		author := uuid.NullUUID{UUID: rule.AuthorID, Valid: rule.AuthorID != uuid.Nil}
+
+		ids := make([]string, 0, len(rule.Reviewers))
+		for _, id := range rule.Reviewers {
+			ids = append(ids, id.String())
+		}
+
+		if _, err := e.ExecContext(ctx, qIns, rules.TeamName, i, rule.Kind, author, pq.Array(ids)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const selectSettings = `
+	SELECT team_name, reassign_on_leave, prefer_working_hours, capacity_policy, review_sla_seconds,
+		escalate_after_seconds, escalation_policy
+	FROM team_settings
+`
+
+func scanSettings(row rowScanner) (entity.TeamSettings, error) {
+	var (
+		s                         entity.TeamSettings
+		slaSeconds, escalateAfter int64
+	)
+
+	if err := row.Scan(
+		&s.TeamName,
+		&s.ReassignOnLeave,
+		&s.PreferWorkingHours,
+		&s.CapacityPolicy,
+		&slaSeconds,
+		&escalateAfter,
+		&s.EscalationPolicy,
+	); err != nil {
+		return entity.TeamSettings{}, err
+	}
+
+	s.ReviewSLA = time.Duration(slaSeconds) * time.Second
+	s.EscalateAfter = time.Duration(escalateAfter) * time.Second
+
+	return s, nil
+}
+
+func (r *TeamRepo) GetSettings(ctx context.Context, teamName string) (entity.TeamSettings, error) {
+	e := r.db.getExec(ctx)
+
+	s, err := scanSettings(e.QueryRowContext(ctx, selectSettings+` WHERE team_name = $1`, teamName))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.DefaultTeamSettings(teamName), nil
+		}
+		return entity.TeamSettings{}, err
+	}
+
+	return s, nil
+}
+
+func (r *TeamRepo) ListEscalating(ctx context.Context) ([]entity.TeamSettings, error) {
+	e := r.db.getExec(ctx)
+
+	rows, err := e.QueryContext(ctx, selectSettings+` WHERE escalate_after_seconds > 0 ORDER BY team_name`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var res []entity.TeamSettings
+
+	for rows.Next() {
+		s, err := scanSettings(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *TeamRepo) UpsertSettings(ctx context.Context, s entity.TeamSettings) error {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		INSERT INTO team_settings (team_name, reassign_on_leave, prefer_working_hours, capacity_policy, review_sla_seconds,
+			escalate_after_seconds, escalation_policy)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (team_name) DO UPDATE
+		SET reassign_on_leave = EXCLUDED.reassign_on_leave,
+			prefer_working_hours = EXCLUDED.prefer_working_hours,
+			capacity_policy = EXCLUDED.capacity_policy,
+			review_sla_seconds = EXCLUDED.review_sla_seconds,
+			escalate_after_seconds = EXCLUDED.escalate_after_seconds,
+			escalation_policy = EXCLUDED.escalation_policy
+	`
+
+	_, err := e.ExecContext(ctx, q,
+		s.TeamName,
+		s.ReassignOnLeave,
+		s.PreferWorkingHours,
+		s.CapacityPolicy,
+		int64(s.ReviewSLA/time.Second),
+		int64(s.EscalateAfter/time.Second),
+		s.EscalationPolicy,
+	)
+	return err
+}
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/Desnn1ch/pr-reviewer-service/internal/infrastructure/persistence/db")
+
+// tracedExecer starts a client span per statement. Query spans end when the query returns,
+// so row iteration is not included.
+type tracedExecer struct {
+	next execer
+}
+
+func (e tracedExecer) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, span := startQuerySpan(ctx, query)
+	defer span.End()
+
+	res, err := e.next.ExecContext(ctx, query, args...)
+	if err == nil {
+		if n, rowsErr := res.RowsAffected(); rowsErr == nil {
+			span.SetAttributes(attribute.Int64("db.rows_affected", n))
+		}
+	}
+	return res, recordErr(span, err)
+}
+
+func (e tracedExecer) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	ctx, span := startQuerySpan(ctx, query)
+	defer span.End()
+
+	rows, err := e.next.QueryContext(ctx, query, args...)
+	return rows, recordErr(span, err)
+}
+
+func (e tracedExecer) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ctx, span := startQuerySpan(ctx, query)
+	defer span.End()
+
+	row := e.next.QueryRowContext(ctx, query, args...)
+	if err := row.Err(); !errors.Is(err, sql.ErrNoRows) {
+		_ = recordErr(span, err)
+	}
+	return row
+}
+
+func startQuerySpan(ctx context.Context, query string) (context.Context, trace.Span) {
+	query = strings.Join(strings.Fields(query), " ")
+
+	op := query
+	if i := strings.IndexByte(op, ' '); i > 0 {
+		op = op[:i]
+	}
+	op = strings.ToUpper(op)
+
+	return tracer.Start(ctx, op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system.name", "postgresql"),
+			attribute.String("db.operation.name", op),
+			attribute.String("db.query.text", query),
+		),
+	)
+}
+
+func recordErr(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
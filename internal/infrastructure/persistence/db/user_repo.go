@@ -3,8 +3,10 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 
 	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
 	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
@@ -18,20 +20,33 @@ func NewUserRepo(db *DB) *UserRepo {
 	return &UserRepo{db: db}
 }
 
-func (r *UserRepo) UpsertMany(ctx context.Context, users []entity.User) error {
+func (r *UserRepo) UpsertMany(ctx context.Context, users []entity.User, at time.Time) error {
 	if len(users) == 0 {
 		return nil
 	}
 
 	e := r.db.getExec(ctx)
 
+	// prev sees the row as it was before the upsert; a new user or a team change is a join.
 	const q = `
-		INSERT INTO users (id, team_name, name, is_active)
-		VALUES ($1, $2, $3, $4)
-		ON CONFLICT (id) DO UPDATE
-		SET team_name = EXCLUDED.team_name,
-			name = EXCLUDED.name,
-			is_active = EXCLUDED.is_active;
+		WITH prev AS (
+			SELECT team_name, is_active FROM users WHERE id = $1
+		), upd AS (
+			INSERT INTO users (id, team_name, name, is_active, skills, is_senior, email)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (id) DO UPDATE
+			SET team_name = EXCLUDED.team_name,
+				name = EXCLUDED.name,
+				is_active = EXCLUDED.is_active,
+				skills = EXCLUDED.skills,
+				is_senior = EXCLUDED.is_senior,
+				email = EXCLUDED.email
+			RETURNING id
+		)
+		INSERT INTO user_activity_changes (user_id, is_active, joined, changed_at)
+		SELECT upd.id, $4, NOT EXISTS (SELECT 1 FROM prev WHERE prev.team_name = $2), $8
+		FROM upd
+		WHERE NOT EXISTS (SELECT 1 FROM prev WHERE prev.team_name = $2 AND prev.is_active = $4)
 	`
 
 	for _, u := range users {
@@ -40,6 +55,10 @@ func (r *UserRepo) UpsertMany(ctx context.Context, users []entity.User) error {
 			u.TeamName,
 			u.Name,
 			u.IsActive,
+			pq.Array(nonNilStrings(u.Skills)),
+			u.IsSenior,
+			sql.NullString{String: u.Email, Valid: u.Email != ""},
+			at,
 		)
 		if err != nil {
 			return err
@@ -53,18 +72,14 @@ func (r *UserRepo) GetByID(ctx context.Context, id uuid.UUID) (entity.User, erro
 	e := r.db.getExec(ctx)
 
 	const q = `
-		SELECT id, team_name, name, is_active
+		SELECT id, team_name, name, is_active, skills, is_senior,
+		       timezone, work_start_minute, work_end_minute, work_days,
+		       max_open_reviews, email
 		FROM users
 		WHERE id = $1
 	`
 
-	var u entity.User
-	err := e.QueryRowContext(ctx, q, id).Scan(
-		&u.ID,
-		&u.TeamName,
-		&u.Name,
-		&u.IsActive,
-	)
+	u, err := scanUser(e.QueryRowContext(ctx, q, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return entity.User{}, common.ErrNotFound
@@ -79,10 +94,12 @@ func (r *UserRepo) ListByTeamName(ctx context.Context, teamName string) ([]entit
 	e := r.db.getExec(ctx)
 
 	const q = `
-		SELECT id, team_name, name, is_active
+		SELECT id, team_name, name, is_active, skills, is_senior,
+		       timezone, work_start_minute, work_end_minute, work_days,
+		       max_open_reviews, email
 		FROM users
 		WHERE team_name = $1
-		ORDER BY name
+		ORDER BY name, id
 	`
 
 	rows, err := e.QueryContext(ctx, q, teamName)
@@ -94,13 +111,43 @@ func (r *UserRepo) ListByTeamName(ctx context.Context, teamName string) ([]entit
 	var res []entity.User
 
 	for rows.Next() {
-		var u entity.User
-		if err := rows.Scan(
-			&u.ID,
-			&u.TeamName,
-			&u.Name,
-			&u.IsActive,
-		); err != nil {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *UserRepo) ListActive(ctx context.Context) ([]entity.User, error) {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		SELECT id, team_name, name, is_active, skills, is_senior,
+		       timezone, work_start_minute, work_end_minute, work_days,
+		       max_open_reviews, email
+		FROM users
+		WHERE is_active = TRUE
+		ORDER BY team_name, name, id
+	`
+
+	rows, err := e.QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var res []entity.User
+
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
 			return nil, err
 		}
 		res = append(res, u)
@@ -117,11 +164,13 @@ func (r *UserRepo) ListActiveByTeamName(ctx context.Context, teamName string) ([
 	e := r.db.getExec(ctx)
 
 	const q = `
-		SELECT id, team_name, name, is_active
+		SELECT id, team_name, name, is_active, skills, is_senior,
+		       timezone, work_start_minute, work_end_minute, work_days,
+		       max_open_reviews, email
 		FROM users
 		WHERE team_name = $1
 		  AND is_active = TRUE
-		ORDER BY name
+		ORDER BY name, id
 	`
 
 	rows, err := e.QueryContext(ctx, q, teamName)
@@ -133,13 +182,8 @@ func (r *UserRepo) ListActiveByTeamName(ctx context.Context, teamName string) ([
 	var res []entity.User
 
 	for rows.Next() {
-		var u entity.User
-		if err := rows.Scan(
-			&u.ID,
-			&u.TeamName,
-			&u.Name,
-			&u.IsActive,
-		); err != nil {
+		u, err := scanUser(rows)
+		if err != nil {
 			return nil, err
 		}
 		res = append(res, u)
@@ -152,16 +196,47 @@ func (r *UserRepo) ListActiveByTeamName(ctx context.Context, teamName string) ([
 	return res, nil
 }
 
-func (r *UserRepo) SetActive(ctx context.Context, id uuid.UUID, active bool) error {
+func (r *UserRepo) SetActive(ctx context.Context, id uuid.UUID, active bool, at time.Time) error {
+	e := r.db.getExec(ctx)
+
+	// The change is logged for the fairness report, which discounts inactive days.
+	const q = `
+		WITH upd AS (
+			UPDATE users
+			SET is_active = $2
+			WHERE id = $1
+			RETURNING id
+		)
+		INSERT INTO user_activity_changes (user_id, is_active, changed_at)
+		SELECT id, $2, $3 FROM upd
+	`
+
+	res, err := e.ExecContext(ctx, q, id, active, at)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepo) SetSkills(ctx context.Context, id uuid.UUID, skills []string) error {
 	e := r.db.getExec(ctx)
 
 	const q = `
 		UPDATE users
-		SET is_active = $2
+		SET skills = $2
 		WHERE id = $1
 	`
 
-	res, err := e.ExecContext(ctx, q, id, active)
+	res, err := e.ExecContext(ctx, q, id, pq.Array(nonNilStrings(skills)))
 	if err != nil {
 		return err
 	}
@@ -176,3 +251,124 @@ func (r *UserRepo) SetActive(ctx context.Context, id uuid.UUID, active bool) err
 
 	return nil
 }
+
+func (r *UserRepo) SetWorkingHours(ctx context.Context, id uuid.UUID, wh *entity.WorkingHours) error {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		UPDATE users
+		SET timezone = $2,
+			work_start_minute = $3,
+			work_end_minute = $4,
+			work_days = $5
+		WHERE id = $1
+	`
+
+	var (
+		tz         sql.NullString
+		start, end sql.NullInt32
+		days       []int64
+	)
+	if wh != nil {
+		tz = sql.NullString{String: wh.Timezone, Valid: true}
+		start = sql.NullInt32{Int32: int32(wh.StartMinute), Valid: true}
+		end = sql.NullInt32{Int32: int32(wh.EndMinute), Valid: true}
+		days = make([]int64, 0, len(wh.Days))
+		for _, d := range wh.Days {
+			days = append(days, int64(d))
+		}
+	}
+
+	res, err := e.ExecContext(ctx, q, id, tz, start, end, pq.Array(days))
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepo) SetMaxOpenReviews(ctx context.Context, id uuid.UUID, limit *int) error {
+	e := r.db.getExec(ctx)
+
+	const q = `
+		UPDATE users
+		SET max_open_reviews = $2
+		WHERE id = $1
+	`
+
+	res, err := e.ExecContext(ctx, q, id, limit)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanUser(row rowScanner) (entity.User, error) {
+	var (
+		u          entity.User
+		tz         sql.NullString
+		start, end sql.NullInt32
+		days       pq.Int64Array
+		maxOpen    sql.NullInt32
+		email      sql.NullString
+	)
+
+	if err := row.Scan(
+		&u.ID,
+		&u.TeamName,
+		&u.Name,
+		&u.IsActive,
+		pq.Array(&u.Skills),
+		&u.IsSenior,
+		&tz,
+		&start,
+		&end,
+		&days,
+		&maxOpen,
+		&email,
+	); err != nil {
+		return entity.User{}, err
+	}
+
+	u.Email = email.String
+
+	if maxOpen.Valid {
+		n := int(maxOpen.Int32)
+		u.MaxOpenReviews = &n
+	}
+
+	if tz.Valid && start.Valid && end.Valid {
+		wh := &entity.WorkingHours{
+			Timezone:    tz.String,
+			StartMinute: int(start.Int32),
+			EndMinute:   int(end.Int32),
+		}
+		for _, d := range days {
+			wh.Days = append(wh.Days, time.Weekday(d))
+		}
+		u.WorkingHours = wh
+	}
+
+	return u, nil
+}
@@ -0,0 +1,91 @@
+package tracing_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/infrastructure/metrics"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/infrastructure/tracing"
+	httpserver "github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/handler"
+)
+
+func TestSetup_Exporters(t *testing.T) {
+	shutdown, err := tracing.Setup(context.Background(), tracing.Config{Exporter: tracing.ExporterNone})
+	if err != nil {
+		t.Fatalf("Setup none returned error: %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown returned error: %v", err)
+	}
+
+	if _, err := tracing.Setup(context.Background(), tracing.Config{Exporter: "zipkin"}); err == nil {
+		t.Fatalf("expected error for unknown exporter")
+	}
+}
+
+// The global provider can be installed only once per process, so HTTP spans are checked in one test.
+func TestTrace_ServerSpanContinuesIncomingTrace(t *testing.T) {
+	logger := slog.New(slog.DiscardHandler)
+	exp := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp)))
+	if _, err := tracing.Setup(context.Background(), tracing.Config{}); err != nil {
+		t.Fatalf("Setup returned error: %v", err)
+	}
+
+	router := httpserver.NewRouter(
+		httpserver.Handlers{
+			Team:    handler.NewTeamHandler(nil, logger),
+			User:    handler.NewUserHandler(nil, logger),
+			PR:      handler.NewPRHandler(nil, logger),
+			Stats:   handler.NewStatsHandler(nil, logger),
+			APIKeys: handler.NewAPIKeyHandler(nil, logger),
+			Health:  handler.NewHealthHandler(),
+		},
+		httpserver.Middlewares{},
+		metrics.New(),
+		logger,
+	)
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(http.MethodGet, "/stats/user?user_id=bad", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	spans := exp.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+
+	if span.Name != "GET /stats/user" {
+		t.Errorf("span name: got %q, want %q", span.Name, "GET /stats/user")
+	}
+	if got := span.SpanContext.TraceID().String(); got != traceID {
+		t.Errorf("trace id: got %s, want %s", got, traceID)
+	}
+	if !span.Parent.IsRemote() {
+		t.Errorf("parent must be the remote caller span")
+	}
+
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, a := range span.Attributes {
+		attrs[a.Key] = a.Value
+	}
+	if got := attrs["http.route"].AsString(); got != "/stats/user" {
+		t.Errorf("http.route: got %q", got)
+	}
+	if got := attrs["http.response.status_code"].AsInt64(); got != http.StatusBadRequest {
+		t.Errorf("http.response.status_code: got %d, want %d", got, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,158 @@
+package httpserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/app/service"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/infrastructure/metrics"
+	httpserver "github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver"
+	respdto "github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/dto/response"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/handler"
+)
+
+type memAPIKeyRepo struct {
+	keys []entity.APIKey
+}
+
+func (r *memAPIKeyRepo) Create(ctx context.Context, key entity.APIKey) error {
+	r.keys = append(r.keys, key)
+	return nil
+}
+
+func (r *memAPIKeyRepo) GetByHash(ctx context.Context, hash string) (entity.APIKey, error) {
+	for _, k := range r.keys {
+		if k.Hash == hash {
+			return k, nil
+		}
+	}
+	return entity.APIKey{}, common.ErrNotFound
+}
+
+func (r *memAPIKeyRepo) List(ctx context.Context) ([]entity.APIKey, error) {
+	return r.keys, nil
+}
+
+func (r *memAPIKeyRepo) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return common.ErrNotFound
+}
+
+func TestRouter_APIKeyScopes(t *testing.T) {
+	logger := slog.New(slog.DiscardHandler)
+	keySvc := service.NewAPIKeyService(&memAPIKeyRepo{}, common.StandardClock{}, "")
+
+	_, statsSecret, err := keySvc.Create(context.Background(), "dashboard", []entity.Scope{entity.ScopeStatsRead})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	router := httpserver.NewRouter(
+		httpserver.Handlers{
+			Team:    handler.NewTeamHandler(nil, logger),
+			User:    handler.NewUserHandler(nil, logger),
+			PR:      handler.NewPRHandler(nil, logger),
+			Stats:   handler.NewStatsHandler(nil, logger),
+			APIKeys: handler.NewAPIKeyHandler(keySvc, logger),
+			Health:  handler.NewHealthHandler(),
+		},
+		httpserver.Middlewares{
+			Auth: handler.NewAuthMiddleware(service.NewAuthService(keySvc, nil, nil), logger),
+		},
+		metrics.New(),
+		logger,
+	)
+
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		auth       string
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "missing header",
+			method:     http.MethodGet,
+			url:        "/stats/user?user_id=bad",
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   "UNAUTHORIZED",
+		},
+		{
+			name:       "unknown key",
+			method:     http.MethodGet,
+			url:        "/stats/user?user_id=bad",
+			auth:       "Bearer prs_unknown",
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   "UNAUTHORIZED",
+		},
+		{
+			name:       "scope granted reaches handler",
+			method:     http.MethodGet,
+			url:        "/stats/user?user_id=bad",
+			auth:       "Bearer " + statsSecret,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "missing scope",
+			method:     http.MethodPost,
+			url:        "/pullRequest/create",
+			auth:       "Bearer " + statsSecret,
+			wantStatus: http.StatusForbidden,
+			wantCode:   "FORBIDDEN",
+		},
+		{
+			name:       "admin routes need admin scope",
+			method:     http.MethodGet,
+			url:        "/admin/apiKeys/list",
+			auth:       "Bearer " + statsSecret,
+			wantStatus: http.StatusForbidden,
+			wantCode:   "FORBIDDEN",
+		},
+		{
+			name:       "health stays open",
+			method:     http.MethodGet,
+			url:        "/healthz",
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.url, nil)
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status: got %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantCode == "" {
+				return
+			}
+
+			var body respdto.Error
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatalf("decode error body: %v", err)
+			}
+			if body.Error.Code != tt.wantCode {
+				t.Fatalf("code: got %q, want %q", body.Error.Code, tt.wantCode)
+			}
+			if tt.wantStatus == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+				t.Fatalf("401 must carry WWW-Authenticate")
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package mapper
+
+import (
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+	req "github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/dto/request"
+	resp "github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/dto/response"
+)
+
+func CreateAPIKeyRequestToScopes(r req.CreateAPIKey) []entity.Scope {
+	scopes := make([]entity.Scope, 0, len(r.Scopes))
+	for _, s := range r.Scopes {
+		scopes = append(scopes, entity.Scope(s))
+	}
+	return scopes
+}
+
+func APIKeyToResponse(k entity.APIKey) resp.APIKey {
+	scopes := make([]string, 0, len(k.Scopes))
+	for _, s := range k.Scopes {
+		scopes = append(scopes, string(s))
+	}
+
+	return resp.APIKey{
+		KeyID:     k.ID.String(),
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Scopes:    scopes,
+		CreatedAt: k.CreatedAt,
+		RevokedAt: k.RevokedAt,
+	}
+}
+
+func APIKeyListToResponse(keys []entity.APIKey) resp.APIKeyList {
+	res := make([]resp.APIKey, 0, len(keys))
+	for _, k := range keys {
+		res = append(res, APIKeyToResponse(k))
+	}
+	return resp.APIKeyList{Keys: res}
+}
@@ -1,6 +1,8 @@
 package mapper
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 
 	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
@@ -8,7 +10,7 @@ import (
 	resp "github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/dto/response"
 )
 
-func CreatePRRequestToArgs(r req.CreatePR) (uuid.UUID, string, uuid.UUID, error) {
+func CreatePRRequestToPR(r req.CreatePR) (entity.PR, error) {
 	var prID uuid.UUID
 	var err error
 
@@ -17,16 +19,22 @@ func CreatePRRequestToArgs(r req.CreatePR) (uuid.UUID, string, uuid.UUID, error)
 	} else {
 		prID, err = uuid.Parse(r.PullRequestID)
 		if err != nil {
-			return uuid.Nil, "", uuid.Nil, err
+			return entity.PR{}, err
 		}
 	}
 
 	authorID, err := uuid.Parse(r.AuthorID)
 	if err != nil {
-		return uuid.Nil, "", uuid.Nil, err
+		return entity.PR{}, err
 	}
 
-	return prID, r.PullRequestName, authorID, nil
+	return entity.PR{
+		ID:           prID,
+		Title:        r.PullRequestName,
+		AuthorID:     authorID,
+		ChangedFiles: r.ChangedFiles,
+		RequiredTags: r.RequiredTags,
+	}, nil
 }
 
 func MergePRRequestToID(r req.MergePR) (uuid.UUID, error) {
@@ -63,8 +71,11 @@ func PRToResponse(pr entity.PR) resp.PullRequest {
 		AuthorID:          pr.AuthorID.String(),
 		Status:            string(pr.Status),
 		AssignedReviewers: reviewers,
+		ChangedFiles:      pr.ChangedFiles,
+		RequiredTags:      pr.RequiredTags,
 		CreatedAt:         pr.CreatedAt,
 		MergedAt:          pr.MergedAt,
+		Version:           pr.Version,
 	}
 }
 
@@ -82,3 +93,134 @@ func PRsToShortResponse(prs []entity.PR) []resp.PullRequestShort {
 
 	return res
 }
+
+func ReviewerChoicesToResponse(choices []entity.ReviewerChoice) []resp.ReviewerReason {
+	res := make([]resp.ReviewerReason, 0, len(choices))
+
+	for _, c := range choices {
+		res = append(res, resp.ReviewerReason{
+			UserID:  c.UserID.String(),
+			Reasons: c.Reasons,
+		})
+	}
+
+	return res
+}
+
+func AssignmentExplainToResponse(prID uuid.UUID, decisions []entity.AssignmentDecision) resp.AssignmentExplain {
+	res := resp.AssignmentExplain{
+		PullRequestID: prID.String(),
+		Decisions:     make([]resp.AssignmentDecision, 0, len(decisions)),
+	}
+
+	for _, d := range decisions {
+		out := resp.AssignmentDecision{
+			ID:        d.ID.String(),
+			Kind:      string(d.Kind),
+			Strategy:  d.Strategy,
+			Pool:      make([]string, 0, len(d.Pool)),
+			Excluded:  make([]resp.ExcludedUser, 0, len(d.Excluded)),
+			Chosen:    make([]resp.ChosenReviewer, 0, len(d.Chosen)),
+			CreatedAt: d.CreatedAt,
+		}
+		if d.ReplacedUserID != uuid.Nil {
+			out.ReplacedUserID = d.ReplacedUserID.String()
+		}
+
+		for _, id := range d.Pool {
+			out.Pool = append(out.Pool, id.String())
+		}
+		for _, x := range d.Excluded {
+			out.Excluded = append(out.Excluded, resp.ExcludedUser{UserID: x.UserID.String(), Reason: x.Reason})
+		}
+		for _, c := range d.Chosen {
+			score := resp.ChoiceScore{
+				RulesMet:       c.Score.RulesMet,
+				TagsCovered:    c.Score.TagsCovered,
+				InWorkingHours: c.Score.InWorkingHours,
+				OpenReviews:    c.Score.OpenReviews,
+				PoolOrder:      c.Score.PoolOrder,
+			}
+			if c.Score.CodeOwnerRank >= 0 {
+				rank := c.Score.CodeOwnerRank
+				score.CodeOwnerRank = &rank
+			}
+
+			out.Chosen = append(out.Chosen, resp.ChosenReviewer{
+				UserID:  c.UserID.String(),
+				Reasons: c.Reasons,
+				Score:   score,
+			})
+		}
+
+		res.Decisions = append(res.Decisions, out)
+	}
+
+	return res
+}
+
+func OverdueReviewsToResponse(teamName string, items []entity.OverdueReview) resp.OverdueReviews {
+	res := resp.OverdueReviews{
+		TeamName: teamName,
+		Items:    make([]resp.OverdueReview, 0, len(items)),
+	}
+
+	for _, o := range items {
+		res.Items = append(res.Items, resp.OverdueReview{
+			PullRequestID:   o.Assignment.PRID.String(),
+			PullRequestName: o.Assignment.Title,
+			AuthorID:        o.Assignment.AuthorID.String(),
+			ReviewerID:      o.Assignment.ReviewerID.String(),
+			AssignedAt:      o.Assignment.AssignedAt,
+			AgeSeconds:      int64(o.Age / time.Second),
+			OverdueSeconds:  int64(o.OverdueBy() / time.Second),
+		})
+	}
+
+	return res
+}
+
+func PREventsToResponse(prID uuid.UUID, events []entity.PREvent) resp.PREvents {
+	res := resp.PREvents{
+		PullRequestID: prID.String(),
+		Events:        make([]resp.PREvent, 0, len(events)),
+	}
+
+	for _, ev := range events {
+		e := resp.PREvent{
+			Kind:      string(ev.Kind),
+			Detail:    ev.Detail,
+			CreatedAt: ev.CreatedAt,
+		}
+		if ev.ReviewerID != uuid.Nil {
+			e.ReviewerID = ev.ReviewerID.String()
+		}
+		if ev.NewReviewerID != uuid.Nil {
+			e.NewReviewerID = ev.NewReviewerID.String()
+		}
+		res.Events = append(res.Events, e)
+	}
+
+	return res
+}
+
+func RecordReviewRequestToArgs(r req.RecordReview) (uuid.UUID, uuid.UUID, error) {
+	prID, err := uuid.Parse(r.PullRequestID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	reviewerID, err := uuid.Parse(r.ReviewerID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	return prID, reviewerID, nil
+}
+
+func RecordReviewToResponse(ev entity.PREvent) resp.RecordReview {
+	return resp.RecordReview{
+		PullRequestID: ev.PRID.String(),
+		Event:         PREventsToResponse(ev.PRID, []entity.PREvent{ev}).Events[0],
+	}
+}
@@ -1,6 +1,8 @@
 package mapper
 
 import (
+	"time"
+
 	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
 	resp "github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/dto/response"
 )
@@ -13,9 +15,97 @@ func ReviewerStatsToResponse(stats []entity.ReviewerStats) resp.ReviewerStats {
 			UserID:          s.UserID.String(),
 			Username:        s.Username,
 			TeamName:        s.TeamName,
+			IsActive:        s.IsActive,
 			AssignedOpenPRs: s.AssignedOpenPRs,
+			TotalReviews:    s.TotalReviews,
+			MergedReviews:   s.MergedReviews,
+			LastAssignedAt:  s.LastAssignedAt,
 		})
 	}
 
 	return resp.ReviewerStats{Items: items}
 }
+
+func UserStatsToResponse(s entity.UserStats) resp.UserStats {
+	res := resp.UserStats{
+		UserID:           s.UserID.String(),
+		Username:         s.Username,
+		TeamName:         s.TeamName,
+		IsActive:         s.IsActive,
+		OpenAssignments:  s.Reviews.OpenAssignments,
+		TotalAssignments: s.Reviews.TotalAssignments,
+		ReassignedAway:   s.Reviews.ReassignedAway,
+		AuthoredPRs:      s.Authored.Authored,
+		MergedPRs:        s.Authored.Merged,
+	}
+
+	if s.Reviews.AvgOpenTime != nil {
+		secs := int64(*s.Reviews.AvgOpenTime / time.Second)
+		res.AvgOpenSeconds = &secs
+	}
+
+	return res
+}
+
+func DurationSummaryToResponse(d entity.DurationSummary) resp.DurationSummary {
+	return resp.DurationSummary{
+		Count:         d.Count,
+		MedianSeconds: int64(d.Median / time.Second),
+		P90Seconds:    int64(d.P90 / time.Second),
+	}
+}
+
+func TeamStatsToResponse(s entity.TeamStats) resp.TeamStats {
+	res := resp.TeamStats{
+		TeamName:    s.TeamName,
+		From:        s.From,
+		To:          s.To,
+		TimeToMerge: DurationSummaryToResponse(s.TimeToMerge),
+		Throughput:  make([]resp.WeeklyThroughput, 0, len(s.Throughput)),
+		Reviewers:   make([]resp.ReviewerLatency, 0, len(s.Reviewers)),
+	}
+
+	for _, w := range s.Throughput {
+		res.Throughput = append(res.Throughput, resp.WeeklyThroughput{
+			WeekStart: w.WeekStart.Format(time.DateOnly),
+			Opened:    w.Opened,
+			Merged:    w.Merged,
+		})
+	}
+
+	for _, r := range s.Reviewers {
+		res.Reviewers = append(res.Reviewers, resp.ReviewerLatency{
+			UserID:            r.UserID.String(),
+			Username:          r.Username,
+			TimeToFirstReview: DurationSummaryToResponse(r.FirstReview),
+		})
+	}
+
+	return res
+}
+
+func FairnessToResponse(r entity.FairnessReport) resp.Fairness {
+	res := resp.Fairness{
+		TeamName:         r.TeamName,
+		From:             r.From,
+		To:               r.To,
+		TotalAssignments: r.Total,
+		Gini:             r.Gini,
+		MaxMinRatio:      r.MaxMinRatio,
+		Members:          make([]resp.MemberFairness, 0, len(r.Members)),
+	}
+
+	for _, m := range r.Members {
+		res.Members = append(res.Members, resp.MemberFairness{
+			UserID:        m.UserID.String(),
+			Username:      m.Username,
+			IsActive:      m.IsActive,
+			AvailableDays: m.AvailableDays,
+			Assignments:   m.Assignments,
+			IdealShare:    m.IdealShare,
+			Deviation:     m.Deviation,
+		})
+	}
+
+	return res
+}
@@ -1,6 +1,8 @@
 package mapper
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 
 	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
@@ -28,6 +30,9 @@ func TeamAddRequestToArgs(r req.TeamAdd) (string, []entity.User, error) {
 			ID:       id,
 			Name:     m.Username,
 			IsActive: m.IsActive,
+			Skills:   m.Skills,
+			IsSenior: m.IsSenior,
+			Email:    m.Email,
 		})
 	}
 
@@ -42,6 +47,9 @@ func TeamToResponse(team entity.Team, members []entity.User) resp.Team {
 			UserID:   u.ID.String(),
 			Username: u.Name,
 			IsActive: u.IsActive,
+			Skills:   u.Skills,
+			IsSenior: u.IsSenior,
+			Email:    u.Email,
 		})
 	}
 
@@ -50,3 +58,146 @@ func TeamToResponse(team entity.Team, members []entity.User) resp.Team {
 		Members:  respMembers,
 	}
 }
+
+func CodeOwnersToResponse(c entity.CodeOwners) resp.CodeOwners {
+	rules := make([]resp.CodeOwnerRule, 0, len(c.Rules))
+
+	for _, rule := range c.Rules {
+		owners := make([]string, 0, len(rule.Owners))
+		for _, id := range rule.Owners {
+			owners = append(owners, id.String())
+		}
+
+		rules = append(rules, resp.CodeOwnerRule{
+			Pattern: rule.Pattern,
+			Owners:  owners,
+		})
+	}
+
+	return resp.CodeOwners{
+		TeamName: c.TeamName,
+		Rules:    rules,
+	}
+}
+
+func SetTeamRulesRequestToArgs(r req.SetTeamRules) (string, []entity.TeamRule, error) {
+	rules := make([]entity.TeamRule, 0, len(r.Rules))
+
+	for _, rr := range r.Rules {
+		rule := entity.TeamRule{Kind: entity.RuleKind(rr.Kind)}
+
+		if rr.AuthorID != "" {
+			id, err := uuid.Parse(rr.AuthorID)
+			if err != nil {
+				return "", nil, err
+			}
+			rule.AuthorID = id
+		}
+
+		for _, s := range rr.ReviewerIDs {
+			id, err := uuid.Parse(s)
+			if err != nil {
+				return "", nil, err
+			}
+			rule.Reviewers = append(rule.Reviewers, id)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return r.TeamName, rules, nil
+}
+
+func TeamRulesToResponse(rs entity.TeamRules) resp.TeamRules {
+	rules := make([]resp.TeamRule, 0, len(rs.Rules))
+
+	for _, rule := range rs.Rules {
+		out := resp.TeamRule{Kind: string(rule.Kind)}
+		if rule.AuthorID != uuid.Nil {
+			out.AuthorID = rule.AuthorID.String()
+		}
+		for _, id := range rule.Reviewers {
+			out.ReviewerIDs = append(out.ReviewerIDs, id.String())
+		}
+		rules = append(rules, out)
+	}
+
+	return resp.TeamRules{
+		TeamName: rs.TeamName,
+		Rules:    rules,
+	}
+}
+
+func SetTeamSettingsRequestToPatch(r req.SetTeamSettings) (entity.TeamSettingsPatch, error) {
+	p := entity.TeamSettingsPatch{
+		ReassignOnLeave:    r.ReassignOnLeave,
+		PreferWorkingHours: r.PreferWorkingHours,
+	}
+	if r.CapacityPolicy != nil {
+		policy := entity.CapacityPolicy(*r.CapacityPolicy)
+		p.CapacityPolicy = &policy
+	}
+	if r.ReviewSLA != nil {
+		sla, err := time.ParseDuration(*r.ReviewSLA)
+		if err != nil {
+			return entity.TeamSettingsPatch{}, err
+		}
+		p.ReviewSLA = &sla
+	}
+	if r.EscalateAfter != nil {
+		after, err := time.ParseDuration(*r.EscalateAfter)
+		if err != nil {
+			return entity.TeamSettingsPatch{}, err
+		}
+		p.EscalateAfter = &after
+	}
+	if r.EscalationPolicy != nil {
+		policy := entity.EscalationPolicy(*r.EscalationPolicy)
+		p.EscalationPolicy = &policy
+	}
+	return p, nil
+}
+
+func TeamSettingsToResponse(s entity.TeamSettings) resp.TeamSettings {
+	return resp.TeamSettings{
+		TeamName:           s.TeamName,
+		ReassignOnLeave:    s.ReassignOnLeave,
+		PreferWorkingHours: s.PreferWorkingHours,
+		CapacityPolicy:     string(s.CapacityPolicy),
+		ReviewSLA:          s.ReviewSLA.String(),
+		EscalateAfter:      s.EscalateAfter.String(),
+		EscalationPolicy:   string(s.EscalationPolicy),
+	}
+}
+
+func SetTeamRoleRequestToEntity(r req.SetTeamRole) (entity.TeamMemberRole, error) {
+	id, err := uuid.Parse(r.UserID)
+	if err != nil {
+		return entity.TeamMemberRole{}, err
+	}
+
+	return entity.TeamMemberRole{
+		TeamName: r.TeamName,
+		UserID:   id,
+		Role:     entity.TeamRole(r.Role),
+	}, nil
+}
+
+func TeamRoleToResponse(r entity.TeamMemberRole) resp.TeamRole {
+	return resp.TeamRole{
+		UserID: r.UserID.String(),
+		Role:   string(r.Role),
+	}
+}
+
+func TeamRolesToResponse(teamName string, roles []entity.TeamMemberRole) resp.TeamRoles {
+	res := make([]resp.TeamRole, 0, len(roles))
+	for _, r := range roles {
+		res = append(res, TeamRoleToResponse(r))
+	}
+
+	return resp.TeamRoles{
+		TeamName: teamName,
+		Roles:    res,
+	}
+}
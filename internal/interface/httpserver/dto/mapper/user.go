@@ -1,6 +1,9 @@
 package mapper
 
 import (
+	"strings"
+	"time"
+
 	"github.com/google/uuid"
 
 	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
@@ -23,5 +26,142 @@ func UserToResponse(u entity.User) resp.User {
 		Username: u.Name,
 		TeamName: u.TeamName,
 		IsActive: u.IsActive,
+		Skills:   u.Skills,
+		IsSenior: u.IsSenior,
+		Email:    u.Email,
+
+		WorkingHours:   WorkingHoursToResponse(u.WorkingHours),
+		MaxOpenReviews: u.MaxOpenReviews,
+	}
+}
+
+func WorkingHoursToResponse(wh *entity.WorkingHours) *resp.WorkingHours {
+	if wh == nil {
+		return nil
+	}
+
+	days := make([]string, 0, len(wh.Days))
+	for _, d := range wh.Days {
+		days = append(days, strings.ToLower(d.String()[:3]))
+	}
+
+	return &resp.WorkingHours{
+		Timezone: wh.Timezone,
+		Start:    entity.FormatClock(wh.StartMinute),
+		End:      entity.FormatClock(wh.EndMinute),
+		Days:     days,
+	}
+}
+
+func SetWorkingHoursRequestToArgs(r req.SetWorkingHours) (uuid.UUID, *entity.WorkingHours, error) {
+	id, err := uuid.Parse(r.UserID)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	if r.WorkingHours == nil {
+		return id, nil, nil
+	}
+
+	start, err := entity.ParseClock(r.WorkingHours.Start)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+	end, err := entity.ParseClock(r.WorkingHours.End)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	days := entity.DefaultWorkDays()
+	if r.WorkingHours.Days != nil {
+		days = make([]time.Weekday, 0, len(r.WorkingHours.Days))
+		seen := make(map[time.Weekday]struct{}, len(r.WorkingHours.Days))
+		for _, s := range r.WorkingHours.Days {
+			d, err := entity.ParseWeekday(s)
+			if err != nil {
+				return uuid.Nil, nil, err
+			}
+			if _, ok := seen[d]; ok {
+				continue
+			}
+			seen[d] = struct{}{}
+			days = append(days, d)
+		}
+	}
+
+	return id, &entity.WorkingHours{
+		Timezone:    r.WorkingHours.Timezone,
+		StartMinute: start,
+		EndMinute:   end,
+		Days:        days,
+	}, nil
+}
+
+func SetSkillsRequestToArgs(r req.SetSkills) (uuid.UUID, []string, error) {
+	id, err := uuid.Parse(r.UserID)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	return id, r.Skills, nil
+}
+
+func SetMaxOpenReviewsRequestToArgs(r req.SetMaxOpenReviews) (uuid.UUID, *int, error) {
+	id, err := uuid.Parse(r.UserID)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	return id, r.MaxOpenReviews, nil
+}
+
+func AddUnavailabilityRequestToEntity(r req.AddUnavailability) (entity.Unavailability, error) {
+	userID, err := uuid.Parse(r.UserID)
+	if err != nil {
+		return entity.Unavailability{}, err
+	}
+
+	return entity.Unavailability{
+		UserID:   userID,
+		StartsAt: r.StartsAt.UTC(),
+		EndsAt:   r.EndsAt.UTC(),
+		Reason:   r.Reason,
+	}, nil
+}
+
+func UpdateUnavailabilityRequestToEntity(r req.UpdateUnavailability) (entity.Unavailability, error) {
+	id, err := uuid.Parse(r.ID)
+	if err != nil {
+		return entity.Unavailability{}, err
+	}
+
+	return entity.Unavailability{
+		ID:       id,
+		StartsAt: r.StartsAt.UTC(),
+		EndsAt:   r.EndsAt.UTC(),
+		Reason:   r.Reason,
+	}, nil
+}
+
+func UnavailabilityToResponse(u entity.Unavailability) resp.Unavailability {
+	return resp.Unavailability{
+		ID:        u.ID.String(),
+		UserID:    u.UserID.String(),
+		StartsAt:  u.StartsAt,
+		EndsAt:    u.EndsAt,
+		Reason:    u.Reason,
+		HandledAt: u.HandledAt,
+	}
+}
+
+func UnavailabilityListToResponse(userID uuid.UUID, items []entity.Unavailability) resp.UnavailabilityList {
+	res := make([]resp.Unavailability, 0, len(items))
+	for _, u := range items {
+		res = append(res, UnavailabilityToResponse(u))
+	}
+
+	return resp.UnavailabilityList{
+		UserID: userID.String(),
+		Items:  res,
 	}
 }
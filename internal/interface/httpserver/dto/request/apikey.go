@@ -0,0 +1,10 @@
+package request
+
+type CreateAPIKey struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+type RevokeAPIKey struct {
+	KeyID string `json:"key_id"`
+}
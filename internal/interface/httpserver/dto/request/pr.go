@@ -1,9 +1,11 @@
 package request
 
 type CreatePR struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
+	PullRequestID   string   `json:"pull_request_id"`
+	PullRequestName string   `json:"pull_request_name"`
+	AuthorID        string   `json:"author_id"`
+	ChangedFiles    []string `json:"changed_files"`
+	RequiredTags    []string `json:"required_tags"`
 }
 
 type MergePR struct {
@@ -14,3 +16,8 @@ type ReassignReviewer struct {
 	PullRequestID string `json:"pull_request_id"`
 	OldUserID     string `json:"old_user_id"`
 }
+
+type RecordReview struct {
+	PullRequestID string `json:"pull_request_id"`
+	ReviewerID    string `json:"reviewer_id"`
+}
@@ -1,12 +1,47 @@
 package request
 
 type TeamMember struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID   string   `json:"user_id"`
+	Username string   `json:"username"`
+	IsActive bool     `json:"is_active"`
+	Skills   []string `json:"skills"`
+	IsSenior bool     `json:"is_senior"`
+	Email    string   `json:"email"`
 }
 
 type TeamAdd struct {
 	TeamName string       `json:"team_name"`
 	Members  []TeamMember `json:"members"`
 }
+
+type SetCodeOwners struct {
+	TeamName string `json:"team_name"`
+	Content  string `json:"content"`
+}
+
+type TeamRule struct {
+	Kind        string   `json:"kind"`
+	AuthorID    string   `json:"author_id"`
+	ReviewerIDs []string `json:"reviewer_ids"`
+}
+
+type SetTeamRules struct {
+	TeamName string     `json:"team_name"`
+	Rules    []TeamRule `json:"rules"`
+}
+
+type SetTeamSettings struct {
+	TeamName           string  `json:"team_name"`
+	ReassignOnLeave    *bool   `json:"reassign_on_leave"`
+	PreferWorkingHours *bool   `json:"prefer_working_hours"`
+	CapacityPolicy     *string `json:"capacity_policy"`
+	ReviewSLA          *string `json:"review_sla"`
+	EscalateAfter      *string `json:"escalate_after"`
+	EscalationPolicy   *string `json:"escalation_policy"`
+}
+
+type SetTeamRole struct {
+	TeamName string `json:"team_name"`
+	UserID   string `json:"user_id"`
+	Role     string `json:"role"`
+}
@@ -1,6 +1,48 @@
 package request
 
+import "time"
+
 type SetIsActive struct {
 	UserID   string `json:"user_id"`
 	IsActive bool   `json:"is_active"`
 }
+
+type SetSkills struct {
+	UserID string   `json:"user_id"`
+	Skills []string `json:"skills"`
+}
+
+type WorkingHours struct {
+	Timezone string   `json:"timezone"`
+	Start    string   `json:"start"`
+	End      string   `json:"end"`
+	Days     []string `json:"days"`
+}
+
+type SetWorkingHours struct {
+	UserID       string        `json:"user_id"`
+	WorkingHours *WorkingHours `json:"working_hours"`
+}
+
+type SetMaxOpenReviews struct {
+	UserID         string `json:"user_id"`
+	MaxOpenReviews *int   `json:"max_open_reviews"`
+}
+
+type AddUnavailability struct {
+	UserID   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason"`
+}
+
+type UpdateUnavailability struct {
+	ID       string    `json:"id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason"`
+}
+
+type DeleteUnavailability struct {
+	ID string `json:"id"`
+}
@@ -0,0 +1,21 @@
+package response
+
+import "time"
+
+type APIKey struct {
+	KeyID     string     `json:"key_id"`
+	Name      string     `json:"name"`
+	Prefix    string     `json:"prefix"`
+	Scopes    []string   `json:"scopes"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+}
+
+type CreateAPIKey struct {
+	Key    APIKey `json:"key"`
+	Secret string `json:"secret"`
+}
+
+type APIKeyList struct {
+	Keys []APIKey `json:"keys"`
+}
@@ -8,8 +8,11 @@ type PullRequest struct {
 	AuthorID          string     `json:"author_id"`
 	Status            string     `json:"status"`
 	AssignedReviewers []string   `json:"assigned_reviewers"`
+	ChangedFiles      []string   `json:"changed_files,omitempty"`
+	RequiredTags      []string   `json:"required_tags,omitempty"`
 	CreatedAt         time.Time  `json:"createdAt"`
 	MergedAt          *time.Time `json:"mergedAt"`
+	Version           int64      `json:"version"`
 }
 
 type PullRequestShort struct {
@@ -19,8 +22,14 @@ type PullRequestShort struct {
 	Status          string `json:"status"`
 }
 
+type ReviewerReason struct {
+	UserID  string   `json:"user_id"`
+	Reasons []string `json:"reasons"`
+}
+
 type CreatePR struct {
-	PR PullRequest `json:"pr"`
+	PR              PullRequest      `json:"pr"`
+	ReviewerReasons []ReviewerReason `json:"reviewer_reasons"`
 }
 
 type MergePR struct {
@@ -30,9 +39,79 @@ type MergePR struct {
 type ReassignReviewer struct {
 	PR         PullRequest `json:"pr"`
 	ReplacedBy string      `json:"replaced_by"`
+	Reasons    []string    `json:"reasons"`
 }
 
 type UserReviews struct {
 	UserID       string             `json:"user_id"`
 	PullRequests []PullRequestShort `json:"pull_requests"`
 }
+
+type ChoiceScore struct {
+	RulesMet       int  `json:"rules_met"`
+	TagsCovered    int  `json:"tags_covered"`
+	InWorkingHours bool `json:"in_working_hours"`
+	CodeOwnerRank  *int `json:"code_owner_rank"`
+	OpenReviews    int  `json:"open_reviews"`
+	PoolOrder      int  `json:"pool_order"`
+}
+
+type ChosenReviewer struct {
+	UserID  string      `json:"user_id"`
+	Reasons []string    `json:"reasons"`
+	Score   ChoiceScore `json:"score"`
+}
+
+type ExcludedUser struct {
+	UserID string `json:"user_id"`
+	Reason string `json:"reason"`
+}
+
+type AssignmentDecision struct {
+	ID             string           `json:"id"`
+	Kind           string           `json:"kind"`
+	Strategy       string           `json:"strategy"`
+	ReplacedUserID string           `json:"replaced_user_id,omitempty"`
+	Pool           []string         `json:"pool"`
+	Excluded       []ExcludedUser   `json:"excluded"`
+	Chosen         []ChosenReviewer `json:"chosen"`
+	CreatedAt      time.Time        `json:"created_at"`
+}
+
+type AssignmentExplain struct {
+	PullRequestID string               `json:"pull_request_id"`
+	Decisions     []AssignmentDecision `json:"decisions"`
+}
+
+type OverdueReview struct {
+	PullRequestID   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	AuthorID        string    `json:"author_id"`
+	ReviewerID      string    `json:"reviewer_id"`
+	AssignedAt      time.Time `json:"assigned_at"`
+	AgeSeconds      int64     `json:"age_seconds"`
+	OverdueSeconds  int64     `json:"overdue_seconds"`
+}
+
+type OverdueReviews struct {
+	TeamName string          `json:"team_name"`
+	Items    []OverdueReview `json:"items"`
+}
+
+type PREvent struct {
+	Kind          string    `json:"kind"`
+	ReviewerID    string    `json:"reviewer_id,omitempty"`
+	NewReviewerID string    `json:"new_reviewer_id,omitempty"`
+	Detail        string    `json:"detail"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type PREvents struct {
+	PullRequestID string    `json:"pull_request_id"`
+	Events        []PREvent `json:"events"`
+}
+
+type RecordReview struct {
+	PullRequestID string  `json:"pull_request_id"`
+	Event         PREvent `json:"event"`
+}
@@ -1,12 +1,81 @@
 package response
 
+import "time"
+
 type ReviewerStat struct {
-	UserID          string `json:"user_id"`
-	Username        string `json:"username"`
-	TeamName        string `json:"team_name"`
-	AssignedOpenPRs int    `json:"assigned_open_prs"`
+	UserID          string     `json:"user_id"`
+	Username        string     `json:"username"`
+	TeamName        string     `json:"team_name"`
+	IsActive        bool       `json:"is_active"`
+	AssignedOpenPRs int        `json:"assigned_open_prs"`
+	TotalReviews    int        `json:"total_reviews"`
+	MergedReviews   int        `json:"merged_reviews"`
+	LastAssignedAt  *time.Time `json:"last_assigned_at"`
 }
 
 type ReviewerStats struct {
 	Items []ReviewerStat `json:"items"`
 }
+
+type DurationSummary struct {
+	Count         int   `json:"count"`
+	MedianSeconds int64 `json:"median_seconds"`
+	P90Seconds    int64 `json:"p90_seconds"`
+}
+
+type WeeklyThroughput struct {
+	WeekStart string `json:"week_start"`
+	Opened    int    `json:"opened"`
+	Merged    int    `json:"merged"`
+}
+
+type ReviewerLatency struct {
+	UserID            string          `json:"user_id"`
+	Username          string          `json:"username"`
+	TimeToFirstReview DurationSummary `json:"time_to_first_review"`
+}
+
+type TeamStats struct {
+	TeamName    string             `json:"team_name"`
+	From        time.Time          `json:"from"`
+	To          time.Time          `json:"to"`
+	TimeToMerge DurationSummary    `json:"time_to_merge"`
+	Throughput  []WeeklyThroughput `json:"throughput"`
+	Reviewers   []ReviewerLatency  `json:"reviewers"`
+}
+
+type MemberFairness struct {
+	UserID        string  `json:"user_id"`
+	Username      string  `json:"username"`
+	IsActive      bool    `json:"is_active"`
+	AvailableDays float64 `json:"available_days"`
+	Assignments   int     `json:"assignments"`
+	IdealShare    float64 `json:"ideal_share"`
+	Deviation     float64 `json:"deviation"`
+}
+
+type Fairness struct {
+	TeamName         string           `json:"team_name"`
+	From             time.Time        `json:"from"`
+	To               time.Time        `json:"to"`
+	TotalAssignments int              `json:"total_assignments"`
+	Gini             float64          `json:"gini"`
+	MaxMinRatio      *float64         `json:"max_min_ratio"`
+	Members          []MemberFairness `json:"members"`
+}
+
+type UserStats struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	TeamName string `json:"team_name"`
+	IsActive bool   `json:"is_active"`
+
+	OpenAssignments  int `json:"open_assignments"`
+	TotalAssignments int `json:"total_assignments"`
+	ReassignedAway   int `json:"reassigned_away"`
+	// AvgOpenSeconds is null when none of the reviewed PRs has been merged.
+	AvgOpenSeconds *int64 `json:"avg_reviewed_pr_open_seconds"`
+
+	AuthoredPRs int `json:"authored_prs"`
+	MergedPRs   int `json:"merged_prs"`
+}
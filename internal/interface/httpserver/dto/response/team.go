@@ -1,9 +1,12 @@
 package response
 
 type TeamMember struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID   string   `json:"user_id"`
+	Username string   `json:"username"`
+	IsActive bool     `json:"is_active"`
+	Skills   []string `json:"skills,omitempty"`
+	IsSenior bool     `json:"is_senior"`
+	Email    string   `json:"email,omitempty"`
 }
 
 type Team struct {
@@ -14,3 +17,44 @@ type Team struct {
 type TeamAdd struct {
 	Team Team `json:"team"`
 }
+
+type CodeOwnerRule struct {
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+}
+
+type CodeOwners struct {
+	TeamName string          `json:"team_name"`
+	Rules    []CodeOwnerRule `json:"rules"`
+}
+
+type TeamRule struct {
+	Kind        string   `json:"kind"`
+	AuthorID    string   `json:"author_id,omitempty"`
+	ReviewerIDs []string `json:"reviewer_ids,omitempty"`
+}
+
+type TeamRules struct {
+	TeamName string     `json:"team_name"`
+	Rules    []TeamRule `json:"rules"`
+}
+
+type TeamSettings struct {
+	TeamName           string `json:"team_name"`
+	ReassignOnLeave    bool   `json:"reassign_on_leave"`
+	PreferWorkingHours bool   `json:"prefer_working_hours"`
+	CapacityPolicy     string `json:"capacity_policy"`
+	ReviewSLA          string `json:"review_sla"`
+	EscalateAfter      string `json:"escalate_after"`
+	EscalationPolicy   string `json:"escalation_policy"`
+}
+
+type TeamRole struct {
+	UserID string `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+type TeamRoles struct {
+	TeamName string     `json:"team_name"`
+	Roles    []TeamRole `json:"roles"`
+}
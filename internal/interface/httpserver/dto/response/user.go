@@ -1,12 +1,53 @@
 package response
 
+import "time"
+
 type User struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID   string   `json:"user_id"`
+	Username string   `json:"username"`
+	TeamName string   `json:"team_name"`
+	IsActive bool     `json:"is_active"`
+	Skills   []string `json:"skills,omitempty"`
+	IsSenior bool     `json:"is_senior"`
+	Email    string   `json:"email,omitempty"`
+
+	WorkingHours   *WorkingHours `json:"working_hours,omitempty"`
+	MaxOpenReviews *int          `json:"max_open_reviews,omitempty"`
+}
+
+type WorkingHours struct {
+	Timezone string   `json:"timezone"`
+	Start    string   `json:"start"`
+	End      string   `json:"end"`
+	Days     []string `json:"days"`
 }
 
 type SetIsActive struct {
 	User User `json:"user"`
 }
+
+type SetSkills struct {
+	User User `json:"user"`
+}
+
+type SetWorkingHours struct {
+	User User `json:"user"`
+}
+
+type SetMaxOpenReviews struct {
+	User User `json:"user"`
+}
+
+type Unavailability struct {
+	ID        string     `json:"id"`
+	UserID    string     `json:"user_id"`
+	StartsAt  time.Time  `json:"starts_at"`
+	EndsAt    time.Time  `json:"ends_at"`
+	Reason    string     `json:"reason"`
+	HandledAt *time.Time `json:"handled_at"`
+}
+
+type UnavailabilityList struct {
+	UserID string           `json:"user_id"`
+	Items  []Unavailability `json:"items"`
+}
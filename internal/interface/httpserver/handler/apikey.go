@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/app/service"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/dto/mapper"
+	req "github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/dto/request"
+	resp "github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/dto/response"
+)
+
+type APIKeyHandler struct {
+	svc *service.APIKeyService
+	log *slog.Logger
+}
+
+func NewAPIKeyHandler(svc *service.APIKeyService, log *slog.Logger) *APIKeyHandler {
+	return &APIKeyHandler{svc: svc, log: log}
+}
+
+func (h *APIKeyHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var body req.CreateAPIKey
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+
+	if body.Name == "" || len(body.Scopes) == 0 {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "missing fields")
+		return
+	}
+
+	key, secret, err := h.svc.Create(r.Context(), body.Name, mapper.CreateAPIKeyRequestToScopes(body))
+	if err != nil {
+		if handleDomainError(w, err) {
+			return
+		}
+		writeInternalError(w, r, h.log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, resp.CreateAPIKey{
+		Key:    mapper.APIKeyToResponse(key),
+		Secret: secret,
+	})
+}
+
+func (h *APIKeyHandler) List(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.svc.List(r.Context())
+	if err != nil {
+		writeInternalError(w, r, h.log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mapper.APIKeyListToResponse(keys))
+}
+
+func (h *APIKeyHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	var body req.RevokeAPIKey
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+
+	if body.KeyID == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "key_id is required")
+		return
+	}
+
+	id, err := uuid.Parse(body.KeyID)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid key_id")
+		return
+	}
+
+	if err := h.svc.Revoke(r.Context(), id); err != nil {
+		if handleDomainError(w, err) {
+			return
+		}
+		writeInternalError(w, r, h.log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/app"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/app/service"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+// AuthMiddleware authenticates requests by an `Authorization: Bearer <api key or JWT>` header and
+// checks route scopes. A nil AuthMiddleware lets every request through.
+type AuthMiddleware struct {
+	svc *service.AuthService
+	log *slog.Logger
+}
+
+func NewAuthMiddleware(svc *service.AuthService, log *slog.Logger) *AuthMiddleware {
+	return &AuthMiddleware{svc: svc, log: log}
+}
+
+// Require rejects requests without a valid key (401) or without the scope (403).
+func (a *AuthMiddleware) Require(scope entity.Scope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if a == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			p, err := a.svc.Authenticate(r.Context(), bearerToken(r))
+			if err != nil {
+				if errors.Is(err, common.ErrUnauthorized) {
+					writeUnauthorized(w)
+					return
+				}
+				writeInternalError(w, r, a.log, err)
+				return
+			}
+
+			if !p.HasScope(scope) {
+				writeError(w, http.StatusForbidden, "FORBIDDEN", "missing scope "+string(scope))
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(app.WithPrincipal(r.Context(), p)))
+		})
+	}
+}
+
+func bearerToken(r *http.Request) string {
+	h := r.Header.Get("Authorization")
+	scheme, token, ok := strings.Cut(h, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// ClientID identifies the authenticated caller, or its IP address when auth is off. Idempotency
+// keys and rate limits are scoped to it.
+func ClientID(r *http.Request) string {
+	if p, ok := app.PrincipalFromContext(r.Context()); ok {
+		switch {
+		case p.IsUser():
+			return "user:" + p.UserID.String()
+		case p.KeyID != uuid.Nil:
+			return "key:" + p.KeyID.String()
+		default:
+			return "key:" + p.Name
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+func writeUnauthorized(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="pr-reviewer-service"`)
+	writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "missing or invalid credentials")
+}
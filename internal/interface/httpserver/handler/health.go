@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	resp "github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/dto/response"
+)
+
+const (
+	healthOK          = "ok"
+	healthUnavailable = "unavailable"
+	healthDraining    = "draining"
+	healthFailed      = "error"
+
+	readinessCheckTimeout = 2 * time.Second
+)
+
+// HealthCheck is a readiness dependency, e.g. the database.
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+type HealthHandler struct {
+	checks   []HealthCheck
+	draining atomic.Bool
+}
+
+func NewHealthHandler(checks ...HealthCheck) *HealthHandler {
+	return &HealthHandler{checks: checks}
+}
+
+// Drain makes readiness fail from now on, so load balancers stop routing here before shutdown.
+func (h *HealthHandler) Drain() {
+	h.draining.Store(true)
+}
+
+func (h *HealthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, resp.Health{Status: healthOK})
+}
+
+func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
+	if h.draining.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, resp.Health{Status: healthDraining})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), readinessCheckTimeout)
+	defer cancel()
+
+	res := resp.Health{
+		Status: healthOK,
+		Checks: make(map[string]resp.HealthCheck, len(h.checks)),
+	}
+	status := http.StatusOK
+
+	for _, c := range h.checks {
+		if err := c.Check(ctx); err != nil {
+			res.Checks[c.Name] = resp.HealthCheck{Status: healthFailed, Error: err.Error()}
+			res.Status = healthUnavailable
+			status = http.StatusServiceUnavailable
+			continue
+		}
+		res.Checks[c.Name] = resp.HealthCheck{Status: healthOK}
+	}
+
+	writeJSON(w, status, res)
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	respdto "github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/dto/response"
+)
+
+func TestHealthHandler_Liveness(t *testing.T) {
+	h := NewHealthHandler(HealthCheck{Name: "database", Check: func(context.Context) error {
+		return errors.New("down")
+	}})
+	h.Drain()
+
+	w := httptest.NewRecorder()
+	h.Liveness(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: got %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+func TestHealthHandler_Readiness(t *testing.T) {
+	ok := func(context.Context) error { return nil }
+	down := func(context.Context) error { return errors.New("schema at version 12, expected 13") }
+
+	tests := []struct {
+		name       string
+		checks     []HealthCheck
+		drain      bool
+		wantStatus int
+		wantBody   string
+		wantChecks map[string]string
+	}{
+		{
+			name:       "all dependencies up",
+			checks:     []HealthCheck{{Name: "database", Check: ok}, {Name: "migrations", Check: ok}},
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+			wantChecks: map[string]string{"database": "ok", "migrations": "ok"},
+		},
+		{
+			name:       "migrations behind",
+			checks:     []HealthCheck{{Name: "database", Check: ok}, {Name: "migrations", Check: down}},
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   "unavailable",
+			wantChecks: map[string]string{"database": "ok", "migrations": "error"},
+		},
+		{
+			name:       "draining",
+			checks:     []HealthCheck{{Name: "database", Check: ok}},
+			drain:      true,
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   "draining",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHealthHandler(tt.checks...)
+			if tt.drain {
+				h.Drain()
+			}
+
+			w := httptest.NewRecorder()
+			h.Readiness(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status: got %d, want %d", w.Code, tt.wantStatus)
+			}
+
+			var body respdto.Health
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if body.Status != tt.wantBody {
+				t.Errorf("status field: got %q, want %q", body.Status, tt.wantBody)
+			}
+			if len(body.Checks) != len(tt.wantChecks) {
+				t.Fatalf("checks: got %v, want %v", body.Checks, tt.wantChecks)
+			}
+			for name, want := range tt.wantChecks {
+				if got := body.Checks[name].Status; got != want {
+					t.Errorf("check %s: got %q, want %q", name, got, want)
+				}
+			}
+			if c, ok := body.Checks["migrations"]; ok && c.Status == "error" && c.Error == "" {
+				t.Errorf("failed check must carry its error")
+			}
+		})
+	}
+}
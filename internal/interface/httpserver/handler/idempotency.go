@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/app/service"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+)
+
+const (
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader marks responses replayed from an earlier request with the same key.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLen  = 255
+	maxIdempotentBodySize = 1 << 20
+)
+
+// IdempotencyMiddleware makes POST requests carrying an Idempotency-Key safe to retry: the first
+// response is stored and replayed for repeats with the same key and body. It must run after
+// authentication, as keys are scoped to the caller. A nil IdempotencyMiddleware does nothing.
+type IdempotencyMiddleware struct {
+	svc *service.IdempotencyService
+	log *slog.Logger
+}
+
+func NewIdempotencyMiddleware(svc *service.IdempotencyService, log *slog.Logger) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{svc: svc, log: log}
+}
+
+func (m *IdempotencyMiddleware) Handle(next http.Handler) http.Handler {
+	if m == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if r.Method != http.MethodPost || key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLen {
+			writeError(w, http.StatusBadRequest, "BAD_REQUEST", "Idempotency-Key is too long")
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxIdempotentBodySize+1))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid body")
+			return
+		}
+		if len(body) > maxIdempotentBodySize {
+			writeError(w, http.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE", "request body too large")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		client := ClientID(r)
+
+		stored, replay, err := m.svc.Begin(r.Context(), client, key, requestHash(r, body))
+		if err != nil {
+			if handleDomainError(w, err) {
+				return
+			}
+			writeInternalError(w, r, m.log, err)
+			return
+		}
+
+		if replay {
+			if stored.ContentType != "" {
+				w.Header().Set("Content-Type", stored.ContentType)
+			}
+			if stored.ETag != "" {
+				w.Header().Set("ETag", stored.ETag)
+			}
+			w.Header().Set(IdempotentReplayedHeader, "true")
+			w.WriteHeader(stored.StatusCode)
+			_, _ = w.Write(stored.Body)
+			return
+		}
+
+		// The outcome is saved even if the client has gone away, so that its retry is answered.
+		ctx := context.WithoutCancel(r.Context())
+		rw := &recordingWriter{ResponseWriter: w}
+		completed := false
+
+		defer func() {
+			if completed {
+				return
+			}
+			if err := m.svc.Release(ctx, client, key); err != nil {
+				m.log.ErrorContext(ctx, "release idempotency key", "error", err)
+			}
+		}()
+
+		next.ServeHTTP(rw, r)
+
+		status := rw.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		// Server errors are not final; the retry should run the request again.
+		if status >= http.StatusInternalServerError {
+			return
+		}
+
+		completed = true
+		err = m.svc.Complete(ctx, entity.IdempotencyRecord{
+			Client:      client,
+			Key:         key,
+			StatusCode:  status,
+			ContentType: rw.Header().Get("Content-Type"),
+			ETag:        rw.Header().Get("ETag"),
+			Body:        rw.body.Bytes(),
+		})
+		if err != nil {
+			m.log.ErrorContext(ctx, "store idempotent response", "error", err)
+		}
+	})
+}
+
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// recordingWriter passes the response through while keeping a copy for replays.
+type recordingWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *recordingWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recordingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -2,8 +2,11 @@ package handler
 
 import (
 	"encoding/json"
+	"log/slog"
 	"net/http"
 
+	"github.com/google/uuid"
+
 	"github.com/Desnn1ch/pr-reviewer-service/internal/app/service"
 	"github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/dto/mapper"
 	req "github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/dto/request"
@@ -12,10 +15,11 @@ import (
 
 type PRHandler struct {
 	svc *service.PRService
+	log *slog.Logger
 }
 
-func NewPRHandler(svc *service.PRService) *PRHandler {
-	return &PRHandler{svc: svc}
+func NewPRHandler(svc *service.PRService, log *slog.Logger) *PRHandler {
+	return &PRHandler{svc: svc, log: log}
 }
 
 func (h *PRHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -29,22 +33,26 @@ func (h *PRHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	id, title, authorID, err := mapper.CreatePRRequestToArgs(body)
+	draft, err := mapper.CreatePRRequestToPR(body)
 	if err != nil {
 		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid ids")
 		return
 	}
 
-	pr, err := h.svc.Create(r.Context(), id, title, authorID)
+	pr, choices, err := h.svc.Create(r.Context(), draft)
 	if err != nil {
 		if handleDomainError(w, err) {
 			return
 		}
-		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal error")
+		writeInternalError(w, r, h.log, err)
 		return
 	}
 
-	writeJSON(w, http.StatusCreated, resp.CreatePR{PR: mapper.PRToResponse(pr)})
+	setETag(w, pr.Version)
+	writeJSON(w, http.StatusCreated, resp.CreatePR{
+		PR:              mapper.PRToResponse(pr),
+		ReviewerReasons: mapper.ReviewerChoicesToResponse(choices),
+	})
 }
 
 func (h *PRHandler) Merge(w http.ResponseWriter, r *http.Request) {
@@ -64,15 +72,22 @@ func (h *PRHandler) Merge(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pr, err := h.svc.Merge(r.Context(), id)
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		return
+	}
+
+	pr, err := h.svc.Merge(r.Context(), id, version)
 	if err != nil {
 		if handleDomainError(w, err) {
 			return
 		}
-		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal error")
+		writeInternalError(w, r, h.log, err)
 		return
 	}
 
+	setETag(w, pr.Version)
 	writeJSON(w, http.StatusOK, resp.MergePR{PR: mapper.PRToResponse(pr)})
 }
 
@@ -93,27 +108,125 @@ func (h *PRHandler) Reassign(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pr, err := h.svc.ReassignReviewer(r.Context(), prID, oldID)
+	version, err := ifMatchVersion(r)
 	if err != nil {
-		if handleDomainError(w, err) {
-			return
-		}
-		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal error")
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
 		return
 	}
 
-	replacedBy := ""
-	for _, id := range pr.Reviewers {
-		if id != oldID {
-			replacedBy = id.String()
-			break
+	pr, choice, err := h.svc.ReassignReviewer(r.Context(), prID, oldID, version)
+	if err != nil {
+		if handleDomainError(w, err) {
+			return
 		}
+		writeInternalError(w, r, h.log, err)
+		return
 	}
 
 	respBody := resp.ReassignReviewer{
 		PR:         mapper.PRToResponse(pr),
-		ReplacedBy: replacedBy,
+		ReplacedBy: choice.UserID.String(),
+		Reasons:    choice.Reasons,
 	}
 
+	setETag(w, pr.Version)
 	writeJSON(w, http.StatusOK, respBody)
 }
+
+func (h *PRHandler) AssignmentExplain(w http.ResponseWriter, r *http.Request) {
+	prIDStr := r.URL.Query().Get("pull_request_id")
+	if prIDStr == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "pull_request_id is required")
+		return
+	}
+
+	prID, err := uuid.Parse(prIDStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid pull_request_id")
+		return
+	}
+
+	decisions, err := h.svc.ExplainAssignment(r.Context(), prID)
+	if err != nil {
+		if handleDomainError(w, err) {
+			return
+		}
+		writeInternalError(w, r, h.log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mapper.AssignmentExplainToResponse(prID, decisions))
+}
+
+func (h *PRHandler) Overdue(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required")
+		return
+	}
+
+	items, err := h.svc.ListOverdue(r.Context(), teamName)
+	if err != nil {
+		if handleDomainError(w, err) {
+			return
+		}
+		writeInternalError(w, r, h.log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mapper.OverdueReviewsToResponse(teamName, items))
+}
+
+func (h *PRHandler) Events(w http.ResponseWriter, r *http.Request) {
+	prIDStr := r.URL.Query().Get("pull_request_id")
+	if prIDStr == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "pull_request_id is required")
+		return
+	}
+
+	prID, err := uuid.Parse(prIDStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid pull_request_id")
+		return
+	}
+
+	events, err := h.svc.ListEvents(r.Context(), prID)
+	if err != nil {
+		if handleDomainError(w, err) {
+			return
+		}
+		writeInternalError(w, r, h.log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mapper.PREventsToResponse(prID, events))
+}
+
+func (h *PRHandler) Review(w http.ResponseWriter, r *http.Request) {
+	var body req.RecordReview
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if body.PullRequestID == "" || body.ReviewerID == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "missing fields")
+		return
+	}
+
+	prID, reviewerID, err := mapper.RecordReviewRequestToArgs(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid ids")
+		return
+	}
+
+	ev, err := h.svc.RecordReview(r.Context(), prID, reviewerID)
+	if err != nil {
+		if handleDomainError(w, err) {
+			return
+		}
+		writeInternalError(w, r, h.log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mapper.RecordReviewToResponse(ev))
+}
@@ -121,6 +121,25 @@ func TestPRHandler_Merge_BadRequests(t *testing.T) {
 	}
 }
 
+func TestPRHandler_Merge_InvalidIfMatch(t *testing.T) {
+	h := &PRHandler{svc: nil}
+
+	for _, ifMatch := range []string{`1`, `W/"1"`, `"abc"`, `"0"`, `"1", "2"`} {
+		t.Run(ifMatch, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/pullRequest/merge",
+				bytes.NewBufferString(`{"pull_request_id":"11111111-1111-1111-1111-111111111111"}`))
+			req.Header.Set("If-Match", ifMatch)
+			w := httptest.NewRecorder()
+
+			h.Merge(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("status: got %d, want %d", w.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
+
 func TestPRHandler_Reassign_BadRequests(t *testing.T) {
 	h := &PRHandler{svc: nil}
 
@@ -177,3 +196,175 @@ func TestPRHandler_Reassign_BadRequests(t *testing.T) {
 		})
 	}
 }
+
+func TestPRHandler_AssignmentExplain_BadRequests(t *testing.T) {
+	h := &PRHandler{svc: nil}
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+	}{
+		{
+			name:       "missing pull_request_id",
+			url:        "/pullRequest/assignmentExplain",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid pull_request_id uuid",
+			url:        "/pullRequest/assignmentExplain?pull_request_id=not-a-uuid",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+
+			h.AssignmentExplain(w, req)
+
+			res := w.Result()
+			defer func() {
+				_ = res.Body.Close()
+			}()
+
+			if res.StatusCode != tt.wantStatus {
+				t.Fatalf("status: got %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+
+			var er respdto.Error
+			if err := json.NewDecoder(res.Body).Decode(&er); err != nil {
+				t.Fatalf("decode error response: %v", err)
+			}
+			if er.Error.Code != BadRequestCode {
+				t.Errorf("error.code: got %q, want %q", er.Error.Code, BadRequestCode)
+			}
+		})
+	}
+}
+
+func TestPRHandler_Overdue_MissingTeamName(t *testing.T) {
+	h := &PRHandler{svc: nil}
+
+	req := httptest.NewRequest(http.MethodGet, "/pullRequest/overdue", nil)
+	w := httptest.NewRecorder()
+
+	h.Overdue(w, req)
+
+	res := w.Result()
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status: got %d, want %d", res.StatusCode, http.StatusBadRequest)
+	}
+
+	var er respdto.Error
+	if err := json.NewDecoder(res.Body).Decode(&er); err != nil {
+		t.Fatalf("decode error response: %v", err)
+	}
+	if er.Error.Code != BadRequestCode {
+		t.Errorf("error.code: got %q, want %q", er.Error.Code, BadRequestCode)
+	}
+}
+
+func TestPRHandler_Events_BadRequests(t *testing.T) {
+	h := &PRHandler{svc: nil}
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+	}{
+		{
+			name:       "missing pull_request_id",
+			url:        "/pullRequest/events",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid pull_request_id uuid",
+			url:        "/pullRequest/events?pull_request_id=not-a-uuid",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+
+			h.Events(w, req)
+
+			res := w.Result()
+			defer func() {
+				_ = res.Body.Close()
+			}()
+
+			if res.StatusCode != tt.wantStatus {
+				t.Fatalf("status: got %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+
+			var er respdto.Error
+			if err := json.NewDecoder(res.Body).Decode(&er); err != nil {
+				t.Fatalf("decode error response: %v", err)
+			}
+			if er.Error.Code != BadRequestCode {
+				t.Errorf("error.code: got %q, want %q", er.Error.Code, BadRequestCode)
+			}
+		})
+	}
+}
+
+func TestPRHandler_Review_BadRequests(t *testing.T) {
+	h := &PRHandler{svc: nil}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{
+			name:       "invalid JSON",
+			body:       "{",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing reviewer_id",
+			body:       `{"pull_request_id":"11111111-1111-1111-1111-111111111111"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid reviewer_id uuid",
+			body:       `{"pull_request_id":"11111111-1111-1111-1111-111111111111","reviewer_id":"not-a-uuid"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/pullRequest/review", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+
+			h.Review(w, req)
+
+			res := w.Result()
+			defer func() {
+				_ = res.Body.Close()
+			}()
+
+			if res.StatusCode != tt.wantStatus {
+				t.Fatalf("status: got %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+
+			var er respdto.Error
+			if err := json.NewDecoder(res.Body).Decode(&er); err != nil {
+				t.Fatalf("decode error response: %v", err)
+			}
+			if er.Error.Code != BadRequestCode {
+				t.Errorf("error.code: got %q, want %q", er.Error.Code, BadRequestCode)
+			}
+		})
+	}
+}
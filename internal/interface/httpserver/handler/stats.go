@@ -1,18 +1,24 @@
 package handler
 
 import (
+	"log/slog"
 	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
 
 	"github.com/Desnn1ch/pr-reviewer-service/internal/app/service"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
 	"github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/dto/mapper"
 )
 
 type StatsHandler struct {
 	svc *service.StatsService
+	log *slog.Logger
 }
 
-func NewStatsHandler(svc *service.StatsService) *StatsHandler {
-	return &StatsHandler{svc: svc}
+func NewStatsHandler(svc *service.StatsService, log *slog.Logger) *StatsHandler {
+	return &StatsHandler{svc: svc, log: log}
 }
 
 func (h *StatsHandler) GetReviewerStats(w http.ResponseWriter, r *http.Request) {
@@ -22,12 +28,106 @@ func (h *StatsHandler) GetReviewerStats(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	stats, err := h.svc.ReviewerStats(r.Context(), teamName)
+	includeInactive := false
+	if v := r.URL.Query().Get("include_inactive"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid include_inactive")
+			return
+		}
+		includeInactive = b
+	}
+
+	sortBy := entity.SortByOpen
+	if v := r.URL.Query().Get("sort"); v != "" {
+		sortBy = entity.ReviewerStatsSort(v)
+		if !sortBy.IsValid() {
+			writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid sort")
+			return
+		}
+	}
+
+	stats, err := h.svc.ReviewerStats(r.Context(), teamName, includeInactive, sortBy)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal error")
+		writeInternalError(w, r, h.log, err)
 		return
 	}
 
 	resp := mapper.ReviewerStatsToResponse(stats)
 	writeJSON(w, http.StatusOK, resp)
 }
+
+func (h *StatsHandler) GetUserStats(w http.ResponseWriter, r *http.Request) {
+	userIDStr := r.URL.Query().Get("user_id")
+	if userIDStr == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required")
+		return
+	}
+
+	id, err := uuid.Parse(userIDStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid user_id")
+		return
+	}
+
+	stats, err := h.svc.UserStats(r.Context(), id)
+	if err != nil {
+		if handleDomainError(w, err) {
+			return
+		}
+		writeInternalError(w, r, h.log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mapper.UserStatsToResponse(stats))
+}
+
+func (h *StatsHandler) GetTeamStats(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required")
+		return
+	}
+
+	from, to, err := parseRange(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		return
+	}
+
+	stats, err := h.svc.TeamStats(r.Context(), teamName, from, to)
+	if err != nil {
+		if handleDomainError(w, err) {
+			return
+		}
+		writeInternalError(w, r, h.log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mapper.TeamStatsToResponse(stats))
+}
+
+func (h *StatsHandler) GetFairness(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required")
+		return
+	}
+
+	from, to, err := parseRange(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		return
+	}
+
+	report, err := h.svc.Fairness(r.Context(), teamName, from, to)
+	if err != nil {
+		if handleDomainError(w, err) {
+			return
+		}
+		writeInternalError(w, r, h.log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mapper.FairnessToResponse(report))
+}
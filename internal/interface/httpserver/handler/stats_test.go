@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	respdto "github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/dto/response"
+)
+
+func TestStatsHandler_ReviewerStats_BadRequests(t *testing.T) {
+	h := &StatsHandler{svc: nil}
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+	}{
+		{
+			name:       "missing team_name",
+			url:        "/stats/reviewers",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid include_inactive",
+			url:        "/stats/reviewers?team_name=backend&include_inactive=maybe",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown sort",
+			url:        "/stats/reviewers?team_name=backend&sort=karma",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+
+			h.GetReviewerStats(w, req)
+
+			res := w.Result()
+			defer func() {
+				_ = res.Body.Close()
+			}()
+
+			if res.StatusCode != tt.wantStatus {
+				t.Fatalf("status: got %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+
+			var er respdto.Error
+			if err := json.NewDecoder(res.Body).Decode(&er); err != nil {
+				t.Fatalf("decode error response: %v", err)
+			}
+			if er.Error.Code != BadRequestCode {
+				t.Errorf("error.code: got %q, want %q", er.Error.Code, BadRequestCode)
+			}
+		})
+	}
+}
+
+func TestStatsHandler_TeamStats_BadRequests(t *testing.T) {
+	h := &StatsHandler{svc: nil}
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+	}{
+		{
+			name:       "missing team_name",
+			url:        "/stats/team?from=2026-10-01&to=2026-10-31",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing from",
+			url:        "/stats/team?team_name=backend&to=2026-10-31",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid to",
+			url:        "/stats/team?team_name=backend&from=2026-10-01&to=yesterday",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+
+			h.GetTeamStats(w, req)
+
+			res := w.Result()
+			defer func() {
+				_ = res.Body.Close()
+			}()
+
+			if res.StatusCode != tt.wantStatus {
+				t.Fatalf("status: got %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+
+			var er respdto.Error
+			if err := json.NewDecoder(res.Body).Decode(&er); err != nil {
+				t.Fatalf("decode error response: %v", err)
+			}
+			if er.Error.Code != BadRequestCode {
+				t.Errorf("error.code: got %q, want %q", er.Error.Code, BadRequestCode)
+			}
+		})
+	}
+}
+
+func TestStatsHandler_Fairness_BadRequests(t *testing.T) {
+	h := &StatsHandler{svc: nil}
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+	}{
+		{
+			name:       "missing team_name",
+			url:        "/stats/fairness?from=2026-10-01&to=2026-10-31",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing from",
+			url:        "/stats/fairness?team_name=backend&to=2026-10-31",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid to",
+			url:        "/stats/fairness?team_name=backend&from=2026-10-01&to=yesterday",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+
+			h.GetFairness(w, req)
+
+			res := w.Result()
+			defer func() {
+				_ = res.Body.Close()
+			}()
+
+			if res.StatusCode != tt.wantStatus {
+				t.Fatalf("status: got %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+
+			var er respdto.Error
+			if err := json.NewDecoder(res.Body).Decode(&er); err != nil {
+				t.Fatalf("decode error response: %v", err)
+			}
+			if er.Error.Code != BadRequestCode {
+				t.Errorf("error.code: got %q, want %q", er.Error.Code, BadRequestCode)
+			}
+		})
+	}
+}
+
+func TestStatsHandler_UserStats_BadRequests(t *testing.T) {
+	h := &StatsHandler{svc: nil}
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+	}{
+		{
+			name:       "missing user_id",
+			url:        "/stats/user",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid user_id uuid",
+			url:        "/stats/user?user_id=not-a-uuid",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+
+			h.GetUserStats(w, req)
+
+			res := w.Result()
+			defer func() {
+				_ = res.Body.Close()
+			}()
+
+			if res.StatusCode != tt.wantStatus {
+				t.Fatalf("status: got %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+
+			var er respdto.Error
+			if err := json.NewDecoder(res.Body).Decode(&er); err != nil {
+				t.Fatalf("decode error response: %v", err)
+			}
+			if er.Error.Code != BadRequestCode {
+				t.Errorf("error.code: got %q, want %q", er.Error.Code, BadRequestCode)
+			}
+		})
+	}
+}
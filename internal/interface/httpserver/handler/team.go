@@ -2,6 +2,7 @@ package handler
 
 import (
 	"encoding/json"
+	"log/slog"
 	"net/http"
 
 	"github.com/Desnn1ch/pr-reviewer-service/internal/app/service"
@@ -12,10 +13,11 @@ import (
 
 type TeamHandler struct {
 	svc *service.TeamService
+	log *slog.Logger
 }
 
-func NewTeamHandler(svc *service.TeamService) *TeamHandler {
-	return &TeamHandler{svc: svc}
+func NewTeamHandler(svc *service.TeamService, log *slog.Logger) *TeamHandler {
+	return &TeamHandler{svc: svc, log: log}
 }
 
 func (h *TeamHandler) Add(w http.ResponseWriter, r *http.Request) {
@@ -45,7 +47,7 @@ func (h *TeamHandler) Add(w http.ResponseWriter, r *http.Request) {
 		if handleDomainError(w, err) {
 			return
 		}
-		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal error")
+		writeInternalError(w, r, h.log, err)
 		return
 	}
 
@@ -65,10 +67,196 @@ func (h *TeamHandler) Get(w http.ResponseWriter, r *http.Request) {
 		if handleDomainError(w, err) {
 			return
 		}
-		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal error")
+		writeInternalError(w, r, h.log, err)
 		return
 	}
 
 	teamResp := mapper.TeamToResponse(team, users)
 	writeJSON(w, http.StatusOK, teamResp)
 }
+
+func (h *TeamHandler) SetCodeOwners(w http.ResponseWriter, r *http.Request) {
+	var body req.SetCodeOwners
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if body.TeamName == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required")
+		return
+	}
+
+	owners, err := h.svc.SetCodeOwners(r.Context(), body.TeamName, body.Content)
+	if err != nil {
+		if handleDomainError(w, err) {
+			return
+		}
+		writeInternalError(w, r, h.log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mapper.CodeOwnersToResponse(owners))
+}
+
+func (h *TeamHandler) GetCodeOwners(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("team_name")
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required")
+		return
+	}
+
+	owners, err := h.svc.GetCodeOwners(r.Context(), name)
+	if err != nil {
+		if handleDomainError(w, err) {
+			return
+		}
+		writeInternalError(w, r, h.log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mapper.CodeOwnersToResponse(owners))
+}
+
+func (h *TeamHandler) SetRules(w http.ResponseWriter, r *http.Request) {
+	var body req.SetTeamRules
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if body.TeamName == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required")
+		return
+	}
+
+	teamName, rules, err := mapper.SetTeamRulesRequestToArgs(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid user id in rules")
+		return
+	}
+
+	res, err := h.svc.SetRules(r.Context(), teamName, rules)
+	if err != nil {
+		if handleDomainError(w, err) {
+			return
+		}
+		writeInternalError(w, r, h.log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mapper.TeamRulesToResponse(res))
+}
+
+func (h *TeamHandler) GetRules(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("team_name")
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required")
+		return
+	}
+
+	rules, err := h.svc.GetRules(r.Context(), name)
+	if err != nil {
+		if handleDomainError(w, err) {
+			return
+		}
+		writeInternalError(w, r, h.log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mapper.TeamRulesToResponse(rules))
+}
+
+func (h *TeamHandler) SetSettings(w http.ResponseWriter, r *http.Request) {
+	var body req.SetTeamSettings
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if body.TeamName == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required")
+		return
+	}
+
+	patch, err := mapper.SetTeamSettingsRequestToPatch(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid duration")
+		return
+	}
+
+	settings, err := h.svc.UpdateSettings(r.Context(), body.TeamName, patch)
+	if err != nil {
+		if handleDomainError(w, err) {
+			return
+		}
+		writeInternalError(w, r, h.log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mapper.TeamSettingsToResponse(settings))
+}
+
+func (h *TeamHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("team_name")
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required")
+		return
+	}
+
+	settings, err := h.svc.GetSettings(r.Context(), name)
+	if err != nil {
+		if handleDomainError(w, err) {
+			return
+		}
+		writeInternalError(w, r, h.log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mapper.TeamSettingsToResponse(settings))
+}
+
+func (h *TeamHandler) SetRole(w http.ResponseWriter, r *http.Request) {
+	var body req.SetTeamRole
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if body.TeamName == "" || body.UserID == "" || body.Role == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "missing fields")
+		return
+	}
+
+	role, err := mapper.SetTeamRoleRequestToEntity(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid user_id")
+		return
+	}
+
+	res, err := h.svc.SetRole(r.Context(), role)
+	if err != nil {
+		if handleDomainError(w, err) {
+			return
+		}
+		writeInternalError(w, r, h.log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mapper.TeamRoleToResponse(res))
+}
+
+func (h *TeamHandler) GetRoles(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("team_name")
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required")
+		return
+	}
+
+	roles, err := h.svc.ListRoles(r.Context(), name)
+	if err != nil {
+		if handleDomainError(w, err) {
+			return
+		}
+		writeInternalError(w, r, h.log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mapper.TeamRolesToResponse(name, roles))
+}
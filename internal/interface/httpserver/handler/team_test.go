@@ -113,3 +113,154 @@ func TestTeamHandler_Get_BadRequests(t *testing.T) {
 		})
 	}
 }
+
+func TestTeamHandler_SetCodeOwners_BadRequests(t *testing.T) {
+	h := &TeamHandler{svc: nil}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{
+			name:       "invalid JSON",
+			body:       "{",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing team_name",
+			body:       `{"content":"*.go @c0f8a1c1-3a21-4b55-9e7c-4f8ba2e9d111"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/team/codeOwners/set", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+
+			h.SetCodeOwners(w, req)
+
+			res := w.Result()
+			defer func() {
+				_ = res.Body.Close()
+			}()
+
+			if res.StatusCode != tt.wantStatus {
+				t.Fatalf("status: got %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+
+			var er respdto.Error
+			if err := json.NewDecoder(res.Body).Decode(&er); err != nil {
+				t.Fatalf("decode error response: %v", err)
+			}
+			if er.Error.Code != BadRequestCode {
+				t.Errorf("error.code: got %q, want %q", er.Error.Code, BadRequestCode)
+			}
+		})
+	}
+}
+
+func TestTeamHandler_SetRules_BadRequests(t *testing.T) {
+	h := &TeamHandler{svc: nil}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{
+			name:       "invalid JSON",
+			body:       "{",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing team_name",
+			body:       `{"rules":[{"kind":"require_senior"}]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid reviewer id",
+			body:       `{"team_name":"backend","rules":[{"kind":"never","author_id":"c0f8a1c1-3a21-4b55-9e7c-4f8ba2e9d111","reviewer_ids":["bob"]}]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/team/rules/set", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+
+			h.SetRules(w, req)
+
+			res := w.Result()
+			defer func() {
+				_ = res.Body.Close()
+			}()
+
+			if res.StatusCode != tt.wantStatus {
+				t.Fatalf("status: got %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+
+			var er respdto.Error
+			if err := json.NewDecoder(res.Body).Decode(&er); err != nil {
+				t.Fatalf("decode error response: %v", err)
+			}
+			if er.Error.Code != BadRequestCode {
+				t.Errorf("error.code: got %q, want %q", er.Error.Code, BadRequestCode)
+			}
+		})
+	}
+}
+
+func TestTeamHandler_SetRole_BadRequests(t *testing.T) {
+	h := &TeamHandler{svc: nil}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{
+			name:       "invalid JSON",
+			body:       "{",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing role",
+			body:       `{"team_name":"backend","user_id":"c0f8a1c1-3a21-4b55-9e7c-4f8ba2e9d111"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid user_id",
+			body:       `{"team_name":"backend","user_id":"bob","role":"lead"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/team/roles/set", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+
+			h.SetRole(w, req)
+
+			res := w.Result()
+			defer func() {
+				_ = res.Body.Close()
+			}()
+
+			if res.StatusCode != tt.wantStatus {
+				t.Fatalf("status: got %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+
+			var er respdto.Error
+			if err := json.NewDecoder(res.Body).Decode(&er); err != nil {
+				t.Fatalf("decode error response: %v", err)
+			}
+			if er.Error.Code != BadRequestCode {
+				t.Errorf("error.code: got %q, want %q", er.Error.Code, BadRequestCode)
+			}
+		})
+	}
+}
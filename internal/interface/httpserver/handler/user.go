@@ -2,6 +2,7 @@ package handler
 
 import (
 	"encoding/json"
+	"log/slog"
 	"net/http"
 
 	"github.com/google/uuid"
@@ -14,10 +15,11 @@ import (
 
 type UserHandler struct {
 	svc *service.UserService
+	log *slog.Logger
 }
 
-func NewUserHandler(svc *service.UserService) *UserHandler {
-	return &UserHandler{svc: svc}
+func NewUserHandler(svc *service.UserService, log *slog.Logger) *UserHandler {
+	return &UserHandler{svc: svc, log: log}
 }
 
 func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
@@ -44,7 +46,7 @@ func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 		if handleDomainError(w, err) {
 			return
 		}
-		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal error")
+		writeInternalError(w, r, h.log, err)
 		return
 	}
 
@@ -52,6 +54,107 @@ func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, resp.SetIsActive{User: userResp})
 }
 
+func (h *UserHandler) SetSkills(w http.ResponseWriter, r *http.Request) {
+	var body req.SetSkills
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+
+	if body.UserID == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required")
+		return
+	}
+
+	id, skills, err := mapper.SetSkillsRequestToArgs(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid user_id")
+		return
+	}
+
+	user, err := h.svc.SetSkills(r.Context(), id, skills)
+	if err != nil {
+		if handleDomainError(w, err) {
+			return
+		}
+		writeInternalError(w, r, h.log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resp.SetSkills{User: mapper.UserToResponse(user)})
+}
+
+func (h *UserHandler) SetWorkingHours(w http.ResponseWriter, r *http.Request) {
+	var body req.SetWorkingHours
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+
+	if body.UserID == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required")
+		return
+	}
+
+	id, wh, err := mapper.SetWorkingHoursRequestToArgs(body)
+	if err != nil {
+		if handleDomainError(w, err) {
+			return
+		}
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid user_id")
+		return
+	}
+
+	user, err := h.svc.SetWorkingHours(r.Context(), id, wh)
+	if err != nil {
+		if handleDomainError(w, err) {
+			return
+		}
+		writeInternalError(w, r, h.log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resp.SetWorkingHours{User: mapper.UserToResponse(user)})
+}
+
+func (h *UserHandler) SetMaxOpenReviews(w http.ResponseWriter, r *http.Request) {
+	var body req.SetMaxOpenReviews
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+
+	if body.UserID == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required")
+		return
+	}
+
+	if body.MaxOpenReviews != nil && *body.MaxOpenReviews < 0 {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "max_open_reviews must not be negative")
+		return
+	}
+
+	id, limit, err := mapper.SetMaxOpenReviewsRequestToArgs(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid user_id")
+		return
+	}
+
+	user, err := h.svc.SetMaxOpenReviews(r.Context(), id, limit)
+	if err != nil {
+		if handleDomainError(w, err) {
+			return
+		}
+		writeInternalError(w, r, h.log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resp.SetMaxOpenReviews{User: mapper.UserToResponse(user)})
+}
+
 func (h *UserHandler) GetReview(w http.ResponseWriter, r *http.Request) {
 	userIDStr := r.URL.Query().Get("user_id")
 	if userIDStr == "" {
@@ -70,7 +173,7 @@ func (h *UserHandler) GetReview(w http.ResponseWriter, r *http.Request) {
 		if handleDomainError(w, err) {
 			return
 		}
-		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal error")
+		writeInternalError(w, r, h.log, err)
 		return
 	}
 
@@ -81,3 +184,120 @@ func (h *UserHandler) GetReview(w http.ResponseWriter, r *http.Request) {
 
 	writeJSON(w, http.StatusOK, respBody)
 }
+
+func (h *UserHandler) AddUnavailability(w http.ResponseWriter, r *http.Request) {
+	var body req.AddUnavailability
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+
+	if body.UserID == "" || body.StartsAt.IsZero() || body.EndsAt.IsZero() {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "missing fields")
+		return
+	}
+
+	u, err := mapper.AddUnavailabilityRequestToEntity(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid user_id")
+		return
+	}
+
+	created, err := h.svc.AddUnavailability(r.Context(), u)
+	if err != nil {
+		if handleDomainError(w, err) {
+			return
+		}
+		writeInternalError(w, r, h.log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, mapper.UnavailabilityToResponse(created))
+}
+
+func (h *UserHandler) UpdateUnavailability(w http.ResponseWriter, r *http.Request) {
+	var body req.UpdateUnavailability
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+
+	if body.ID == "" || body.StartsAt.IsZero() || body.EndsAt.IsZero() {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "missing fields")
+		return
+	}
+
+	u, err := mapper.UpdateUnavailabilityRequestToEntity(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid id")
+		return
+	}
+
+	updated, err := h.svc.UpdateUnavailability(r.Context(), u)
+	if err != nil {
+		if handleDomainError(w, err) {
+			return
+		}
+		writeInternalError(w, r, h.log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mapper.UnavailabilityToResponse(updated))
+}
+
+func (h *UserHandler) DeleteUnavailability(w http.ResponseWriter, r *http.Request) {
+	var body req.DeleteUnavailability
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+
+	if body.ID == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "id is required")
+		return
+	}
+
+	id, err := uuid.Parse(body.ID)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid id")
+		return
+	}
+
+	if err := h.svc.DeleteUnavailability(r.Context(), id); err != nil {
+		if handleDomainError(w, err) {
+			return
+		}
+		writeInternalError(w, r, h.log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *UserHandler) ListUnavailability(w http.ResponseWriter, r *http.Request) {
+	userIDStr := r.URL.Query().Get("user_id")
+	if userIDStr == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required")
+		return
+	}
+
+	id, err := uuid.Parse(userIDStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid user_id")
+		return
+	}
+
+	items, err := h.svc.ListUnavailability(r.Context(), id)
+	if err != nil {
+		if handleDomainError(w, err) {
+			return
+		}
+		writeInternalError(w, r, h.log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mapper.UnavailabilityListToResponse(id, items))
+}
@@ -111,3 +111,220 @@ func TestUserHandler_GetReview_BadRequests(t *testing.T) {
 		})
 	}
 }
+
+func TestUserHandler_SetSkills_BadRequests(t *testing.T) {
+	h := &UserHandler{svc: nil}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{
+			name:       "invalid JSON",
+			body:       "{",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing user_id",
+			body:       `{"skills": ["go"]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid user_id format",
+			body:       `{"user_id": "not-a-uuid", "skills": ["go"]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/users/setSkills", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+
+			h.SetSkills(w, req)
+
+			res := w.Result()
+			defer func() {
+				_ = res.Body.Close()
+			}()
+
+			if res.StatusCode != tt.wantStatus {
+				t.Fatalf("status: got %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+
+			var er respdto.Error
+			if err := json.NewDecoder(res.Body).Decode(&er); err != nil {
+				t.Fatalf("decode error response: %v", err)
+			}
+			if er.Error.Code != "BAD_REQUEST" {
+				t.Errorf("error.code: got %q, want %q", er.Error.Code, "BAD_REQUEST")
+			}
+		})
+	}
+}
+
+func TestUserHandler_AddUnavailability_BadRequests(t *testing.T) {
+	h := &UserHandler{svc: nil}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{
+			name:       "invalid JSON",
+			body:       "{",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid time format",
+			body:       `{"user_id": "11111111-1111-1111-1111-111111111111", "starts_at": "tomorrow", "ends_at": "2025-08-10T00:00:00Z"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing ends_at",
+			body:       `{"user_id": "11111111-1111-1111-1111-111111111111", "starts_at": "2025-08-01T00:00:00Z"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid user_id format",
+			body:       `{"user_id": "not-a-uuid", "starts_at": "2025-08-01T00:00:00Z", "ends_at": "2025-08-10T00:00:00Z"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/users/availability/add", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+
+			h.AddUnavailability(w, req)
+
+			res := w.Result()
+			defer func() {
+				_ = res.Body.Close()
+			}()
+
+			if res.StatusCode != tt.wantStatus {
+				t.Fatalf("status: got %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+
+			var er respdto.Error
+			if err := json.NewDecoder(res.Body).Decode(&er); err != nil {
+				t.Fatalf("decode error response: %v", err)
+			}
+			if er.Error.Code != "BAD_REQUEST" {
+				t.Errorf("error.code: got %q, want %q", er.Error.Code, "BAD_REQUEST")
+			}
+		})
+	}
+}
+
+func TestUserHandler_SetWorkingHours_BadRequests(t *testing.T) {
+	h := &UserHandler{svc: nil}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "invalid JSON",
+			body:       "{",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "missing user_id",
+			body:       `{"working_hours": null}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "invalid user_id format",
+			body:       `{"user_id": "not-a-uuid", "working_hours": null}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "invalid start time",
+			body:       `{"user_id": "11111111-1111-1111-1111-111111111111", "working_hours": {"timezone": "UTC", "start": "9am", "end": "18:00"}}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "INVALID_WORKING_HOURS",
+		},
+		{
+			name:       "invalid weekday",
+			body:       `{"user_id": "11111111-1111-1111-1111-111111111111", "working_hours": {"timezone": "UTC", "start": "09:00", "end": "18:00", "days": ["funday"]}}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "INVALID_WORKING_HOURS",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/users/setWorkingHours", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+
+			h.SetWorkingHours(w, req)
+
+			res := w.Result()
+			defer func() {
+				_ = res.Body.Close()
+			}()
+
+			if res.StatusCode != tt.wantStatus {
+				t.Fatalf("status: got %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+
+			var er respdto.Error
+			if err := json.NewDecoder(res.Body).Decode(&er); err != nil {
+				t.Fatalf("decode error response: %v", err)
+			}
+			if er.Error.Code != tt.wantCode {
+				t.Errorf("error.code: got %q, want %q", er.Error.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestUserHandler_SetMaxOpenReviews_BadRequests(t *testing.T) {
+	h := &UserHandler{svc: nil}
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "invalid JSON", body: "{"},
+		{name: "missing user_id", body: `{"max_open_reviews": 3}`},
+		{name: "negative limit", body: `{"user_id": "11111111-1111-1111-1111-111111111111", "max_open_reviews": -1}`},
+		{name: "invalid user_id format", body: `{"user_id": "not-a-uuid", "max_open_reviews": 3}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/users/setMaxOpenReviews", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+
+			h.SetMaxOpenReviews(w, req)
+
+			res := w.Result()
+			defer func() {
+				_ = res.Body.Close()
+			}()
+
+			if res.StatusCode != http.StatusBadRequest {
+				t.Fatalf("status: got %d, want %d", res.StatusCode, http.StatusBadRequest)
+			}
+
+			var er respdto.Error
+			if err := json.NewDecoder(res.Body).Decode(&er); err != nil {
+				t.Fatalf("decode error response: %v", err)
+			}
+			if er.Error.Code != "BAD_REQUEST" {
+				t.Errorf("error.code: got %q, want %q", er.Error.Code, "BAD_REQUEST")
+			}
+		})
+	}
+}
@@ -3,7 +3,14 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
 
 	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
 	resp "github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/dto/response"
@@ -15,7 +22,14 @@ func writeJSON(w http.ResponseWriter, status int, v any) {
 	_ = json.NewEncoder(w).Encode(v)
 }
 
+// ErrorCodeRecorder is implemented by response writers that want to know the error code
+// of the response, such as the access log. Writers in between are reached through Unwrap.
+type ErrorCodeRecorder interface {
+	SetErrorCode(code string)
+}
+
 func writeError(w http.ResponseWriter, status int, code, message string) {
+	recordErrorCode(w, code)
 	writeJSON(w, status, resp.Error{
 		Error: resp.ErrorBody{
 			Code:    code,
@@ -24,6 +38,36 @@ func writeError(w http.ResponseWriter, status int, code, message string) {
 	})
 }
 
+// WriteError writes the standard error body for middlewares outside this package.
+func WriteError(w http.ResponseWriter, status int, code, message string) {
+	writeError(w, status, code, message)
+}
+
+func recordErrorCode(w http.ResponseWriter, code string) {
+	for {
+		switch t := w.(type) {
+		case ErrorCodeRecorder:
+			t.SetErrorCode(code)
+			return
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = t.Unwrap()
+		default:
+			return
+		}
+	}
+}
+
+// writeInternalError hides err from the client and logs it with the request context.
+func writeInternalError(w http.ResponseWriter, r *http.Request, log *slog.Logger, err error) {
+	log.ErrorContext(r.Context(), "request failed",
+		"request_id", middleware.GetReqID(r.Context()),
+		"method", r.Method,
+		"path", r.URL.Path,
+		"error", err,
+	)
+	writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal error")
+}
+
 func handleDomainError(w http.ResponseWriter, err error) bool {
 	switch {
 	case errors.Is(err, common.ErrTeamExists):
@@ -40,8 +84,93 @@ func handleDomainError(w http.ResponseWriter, err error) bool {
 		writeError(w, http.StatusNotFound, "NOT_FOUND", err.Error())
 	case errors.Is(err, common.ErrUserInAnotherTeam):
 		writeError(w, http.StatusBadRequest, "USER_IN_ANOTHER_TEAM", err.Error())
+	case errors.Is(err, common.ErrInvalidCodeOwners):
+		writeError(w, http.StatusBadRequest, "INVALID_CODEOWNERS", err.Error())
+	case errors.Is(err, common.ErrInvalidInterval):
+		writeError(w, http.StatusBadRequest, "INVALID_INTERVAL", err.Error())
+	case errors.Is(err, common.ErrInvalidWorkingHours):
+		writeError(w, http.StatusBadRequest, "INVALID_WORKING_HOURS", err.Error())
+	case errors.Is(err, common.ErrInvalidTeamSettings):
+		writeError(w, http.StatusBadRequest, "INVALID_SETTINGS", err.Error())
+	case errors.Is(err, common.ErrInvalidRules):
+		writeError(w, http.StatusBadRequest, "INVALID_RULES", err.Error())
+	case errors.Is(err, common.ErrRulesUnsatisfied):
+		writeError(w, http.StatusConflict, "RULES_UNSATISFIED", err.Error())
+	case errors.Is(err, common.ErrInvalidAPIKey):
+		writeError(w, http.StatusBadRequest, "INVALID_API_KEY", err.Error())
+	case errors.Is(err, common.ErrUnauthorized):
+		writeUnauthorized(w)
+	case errors.Is(err, common.ErrForbidden):
+		writeError(w, http.StatusForbidden, "FORBIDDEN", err.Error())
+	case errors.Is(err, common.ErrInvalidRole):
+		writeError(w, http.StatusBadRequest, "INVALID_ROLE", err.Error())
+	case errors.Is(err, common.ErrIdempotencyMismatch):
+		writeError(w, http.StatusUnprocessableEntity, "IDEMPOTENCY_KEY_REUSED", err.Error())
+	case errors.Is(err, common.ErrIdempotencyPending):
+		writeError(w, http.StatusConflict, "IDEMPOTENCY_IN_PROGRESS", err.Error())
+	case errors.Is(err, common.ErrConflict):
+		writeError(w, http.StatusPreconditionFailed, "VERSION_CONFLICT", err.Error())
 	default:
 		return false
 	}
 	return true
 }
+
+// setETag sends the version of a resource as its strong entity tag.
+func setETag(w http.ResponseWriter, version int64) {
+	w.Header().Set("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// ifMatchVersion reads the version from an If-Match header holding one entity tag sent by
+// setETag. It returns zero, meaning any version, when the header is absent or "*".
+func ifMatchVersion(r *http.Request) (int64, error) {
+	v := strings.TrimSpace(r.Header.Get("If-Match"))
+	if v == "" || v == "*" {
+		return 0, nil
+	}
+
+	tag, err := strconv.Unquote(v)
+	if err != nil || !strings.HasPrefix(v, `"`) {
+		return 0, errors.New("invalid If-Match")
+	}
+
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version < 1 {
+		return 0, errors.New("invalid If-Match")
+	}
+
+	return version, nil
+}
+
+// parseRange reads the from/to query parameters as RFC 3339 timestamps or dates;
+// a date in to includes that whole day.
+func parseRange(r *http.Request) (time.Time, time.Time, error) {
+	q := r.URL.Query()
+
+	from, err := parseTimeParam(q.Get("from"), false)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid from: %w", err)
+	}
+
+	to, err := parseTimeParam(q.Get("to"), true)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid to: %w", err)
+	}
+
+	return from, to, nil
+}
+
+func parseTimeParam(v string, endOfDay bool) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, errors.New("is required")
+	}
+
+	if d, err := time.Parse(time.DateOnly, v); err == nil {
+		if endOfDay {
+			d = d.AddDate(0, 0, 1)
+		}
+		return d, nil
+	}
+
+	return time.Parse(time.RFC3339, v)
+}
@@ -0,0 +1,118 @@
+package httpserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/app/service"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+	respdto "github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/dto/response"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/handler"
+)
+
+type memIdempotencyRepo struct {
+	records map[string]entity.IdempotencyRecord
+}
+
+func (r *memIdempotencyRepo) Reserve(ctx context.Context, rec entity.IdempotencyRecord) (entity.IdempotencyRecord, bool, error) {
+	if stored, ok := r.records[rec.Client+"|"+rec.Key]; ok {
+		return stored, false, nil
+	}
+	r.records[rec.Client+"|"+rec.Key] = rec
+	return rec, true, nil
+}
+
+func (r *memIdempotencyRepo) Complete(ctx context.Context, rec entity.IdempotencyRecord) error {
+	stored := r.records[rec.Client+"|"+rec.Key]
+	stored.StatusCode, stored.ContentType, stored.ETag, stored.Body = rec.StatusCode, rec.ContentType, rec.ETag, rec.Body
+	r.records[rec.Client+"|"+rec.Key] = stored
+	return nil
+}
+
+func (r *memIdempotencyRepo) Delete(ctx context.Context, client, key string) error {
+	delete(r.records, client+"|"+key)
+	return nil
+}
+
+func (r *memIdempotencyRepo) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	logger := slog.New(slog.DiscardHandler)
+	svc := service.NewIdempotencyService(&memIdempotencyRepo{records: map[string]entity.IdempotencyRecord{}}, common.StandardClock{}, time.Hour, logger)
+
+	calls := 0
+	status := http.StatusOK
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", strconv.Quote(strconv.Itoa(calls)))
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"call":` + strconv.Itoa(calls) + `}`))
+	})
+	h := handler.NewIdempotencyMiddleware(svc, logger).Handle(next)
+
+	do := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/reassign", strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(handler.IdempotencyKeyHeader, key)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := do("k1", `{"pull_request_id":"pr-1"}`)
+	if first.Code != http.StatusOK || first.Body.String() != `{"call":1}` || first.Header().Get("ETag") != `"1"` {
+		t.Fatalf("first: %d %s", first.Code, first.Body.String())
+	}
+
+	replayed := do("k1", `{"pull_request_id":"pr-1"}`)
+	if calls != 1 {
+		t.Fatalf("expected the handler to run once, ran %d times", calls)
+	}
+	if replayed.Code != http.StatusOK || replayed.Body.String() != `{"call":1}` ||
+		replayed.Header().Get(handler.IdempotentReplayedHeader) != "true" ||
+		replayed.Header().Get("Content-Type") != "application/json" ||
+		replayed.Header().Get("ETag") != first.Header().Get("ETag") {
+		t.Fatalf("replay: %d %v %s", replayed.Code, replayed.Header(), replayed.Body.String())
+	}
+
+	mismatch := do("k1", `{"pull_request_id":"pr-2"}`)
+	if mismatch.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("different body: expected 422, got %d", mismatch.Code)
+	}
+	var body respdto.Error
+	if err := json.Unmarshal(mismatch.Body.Bytes(), &body); err != nil || body.Error.Code != "IDEMPOTENCY_KEY_REUSED" {
+		t.Fatalf("different body: unexpected error %s", mismatch.Body.String())
+	}
+
+	if do("", `{"pull_request_id":"pr-1"}`); calls != 2 {
+		t.Fatalf("requests without a key must not be deduplicated")
+	}
+
+	status = http.StatusInternalServerError
+	do("k2", `{}`)
+	status = http.StatusOK
+	if retry := do("k2", `{}`); retry.Code != http.StatusOK || calls != 4 {
+		t.Fatalf("a server error must not be replayed: %d after %d calls", retry.Code, calls)
+	}
+
+	if long := do(strings.Repeat("k", 256), `{}`); long.Code != http.StatusBadRequest {
+		t.Fatalf("long key: expected 400, got %d", long.Code)
+	}
+
+	large := do("k3", strings.Repeat(" ", 1<<20+1))
+	if err := json.Unmarshal(large.Body.Bytes(), &body); err != nil || large.Code != http.StatusRequestEntityTooLarge || body.Error.Code != "PAYLOAD_TOO_LARGE" {
+		t.Fatalf("large body: %d %s", large.Code, large.Body.String())
+	}
+}
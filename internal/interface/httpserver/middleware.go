@@ -1,14 +1,32 @@
 package httpserver
 
 import (
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"time"
+
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/handler"
 )
 
-func UseMiddlewares(r chi.Router) {
+var tracer = otel.Tracer("github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver")
+
+func UseMiddlewares(r chi.Router, logger *slog.Logger, mw Middlewares) {
 	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
+	r.Use(RealIP(mw.TrustedProxies))
+	r.Use(Trace)
+	r.Use(AccessLog(logger))
 	r.Use(middleware.Recoverer)
 
 	r.Use(cors.Handler(cors.Options{
@@ -18,4 +36,174 @@ func UseMiddlewares(r chi.Router) {
 		AllowCredentials: false,
 		MaxAge:           300,
 	}))
+
+	r.Use(mw.RateLimit.PerIP)
+}
+
+// RealIP replaces RemoteAddr with the client address from X-Forwarded-For or X-Real-IP, but only
+// when the request comes from one of the trusted proxies: anyone else could pick their own address
+// and get a fresh per-IP bucket with every request. In X-Forwarded-For the rightmost address not
+// belonging to a trusted proxy is taken, since entries to the left of it are client-controlled.
+func RealIP(trusted []netip.Prefix) func(http.Handler) http.Handler {
+	isTrusted := func(addr netip.Addr) bool {
+		for _, p := range trusted {
+			if p.Contains(addr.Unmap()) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(next http.Handler) http.Handler {
+		if len(trusted) == 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			host, _, err := net.SplitHostPort(r.RemoteAddr)
+			if err != nil {
+				host = r.RemoteAddr
+			}
+			peer, err := netip.ParseAddr(host)
+			if err != nil || !isTrusted(peer) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			var client netip.Addr
+			if xff := r.Header.Values("X-Forwarded-For"); len(xff) > 0 {
+				hops := strings.Split(strings.Join(xff, ","), ",")
+				for i := len(hops) - 1; i >= 0; i-- {
+					addr, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+					if err != nil {
+						break
+					}
+					client = addr
+					if !isTrusted(addr) {
+						break
+					}
+				}
+			} else if addr, err := netip.ParseAddr(strings.TrimSpace(r.Header.Get("X-Real-IP"))); err == nil {
+				client = addr
+			}
+
+			if client.IsValid() {
+				r.RemoteAddr = client.Unmap().String()
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Metrics receives per-request observations and serves them on /metrics.
+type Metrics interface {
+	ObserveRequest(method, route string, status int, elapsed time.Duration)
+	Handler() http.Handler
+}
+
+// ObserveRequests labels requests by chi route pattern, so path parameters do not blow up cardinality.
+func ObserveRequests(m Metrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			next.ServeHTTP(ww, r)
+
+			route := chi.RouteContext(r.Context()).RoutePattern()
+			if route == "" {
+				route = "unmatched"
+			}
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			m.ObserveRequest(r.Method, route, status, time.Since(start))
+		})
+	}
+}
+
+// Trace starts a server span per request, continuing the caller's trace from the request headers.
+// The span is renamed to the chi route pattern once routing is done.
+func Trace(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracer.Start(ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("url.path", r.URL.Path),
+				attribute.String("http.request_id", middleware.GetReqID(r.Context())),
+			),
+		)
+		defer span.End()
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		if route := chi.RouteContext(r.Context()).RoutePattern(); route != "" {
+			span.SetName(r.Method + " " + route)
+			span.SetAttributes(attribute.String("http.route", route))
+		}
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	})
+}
+
+type accessLogWriter struct {
+	middleware.WrapResponseWriter
+	errorCode string
+}
+
+var _ handler.ErrorCodeRecorder = (*accessLogWriter)(nil)
+
+func (w *accessLogWriter) SetErrorCode(code string) { w.errorCode = code }
+
+// AccessLog writes one line per request with its request ID, route, status, latency
+// and the API error code, if the response carried one.
+func AccessLog(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			lw := &accessLogWriter{WrapResponseWriter: middleware.NewWrapResponseWriter(w, r.ProtoMajor)}
+
+			next.ServeHTTP(lw, r)
+
+			status := lw.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			attrs := []slog.Attr{
+				slog.String("request_id", middleware.GetReqID(r.Context())),
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.String("route", chi.RouteContext(r.Context()).RoutePattern()),
+				slog.Int("status", status),
+				slog.Duration("latency", time.Since(start)),
+				slog.Int("bytes", lw.BytesWritten()),
+			}
+			if lw.errorCode != "" {
+				attrs = append(attrs, slog.String("error_code", lw.errorCode))
+			}
+			if sc := trace.SpanContextFromContext(r.Context()); sc.IsValid() {
+				attrs = append(attrs, slog.String("trace_id", sc.TraceID().String()))
+			}
+
+			level := slog.LevelInfo
+			if status >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+
+			logger.LogAttrs(r.Context(), level, "http request", attrs...)
+		})
+	}
 }
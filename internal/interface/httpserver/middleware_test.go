@@ -0,0 +1,90 @@
+package httpserver_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/infrastructure/metrics"
+	httpserver "github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/handler"
+)
+
+func TestAccessLog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	router := httpserver.NewRouter(
+		httpserver.Handlers{
+			Team:    handler.NewTeamHandler(nil, logger),
+			User:    handler.NewUserHandler(nil, logger),
+			PR:      handler.NewPRHandler(nil, logger),
+			Stats:   handler.NewStatsHandler(nil, logger),
+			APIKeys: handler.NewAPIKeyHandler(nil, logger),
+			Health:  handler.NewHealthHandler(),
+		},
+		httpserver.Middlewares{},
+		metrics.New(),
+		logger,
+	)
+
+	tests := []struct {
+		name          string
+		url           string
+		wantRoute     string
+		wantStatus    float64
+		wantErrorCode string
+	}{
+		{
+			name:          "api error carries its code",
+			url:           "/stats/user?user_id=bad",
+			wantRoute:     "/stats/user",
+			wantStatus:    http.StatusBadRequest,
+			wantErrorCode: "BAD_REQUEST",
+		},
+		{
+			name:       "unknown route",
+			url:        "/nope",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			req.Header.Set("X-Request-Id", "req-42")
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			var entry map[string]any
+			if err := json.Unmarshal([]byte(strings.TrimSpace(buf.String())), &entry); err != nil {
+				t.Fatalf("expected one JSON access log line, got %q: %v", buf.String(), err)
+			}
+
+			if entry["msg"] != "http request" {
+				t.Errorf("msg: got %v", entry["msg"])
+			}
+			if entry["request_id"] != "req-42" {
+				t.Errorf("request_id: got %v, want req-42", entry["request_id"])
+			}
+			if entry["route"] != tt.wantRoute {
+				t.Errorf("route: got %v, want %q", entry["route"], tt.wantRoute)
+			}
+			if entry["status"] != tt.wantStatus {
+				t.Errorf("status: got %v, want %v", entry["status"], tt.wantStatus)
+			}
+			if _, ok := entry["latency"]; !ok {
+				t.Errorf("latency missing")
+			}
+			code, _ := entry["error_code"].(string)
+			if code != tt.wantErrorCode {
+				t.Errorf("error_code: got %q, want %q", code, tt.wantErrorCode)
+			}
+		})
+	}
+}
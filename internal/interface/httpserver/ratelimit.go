@@ -0,0 +1,151 @@
+package httpserver
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/handler"
+)
+
+// idleBucketTTL is how long a client's bucket is kept after its last request.
+const idleBucketTTL = 10 * time.Minute
+
+// unlimitedPaths are probes and metrics scrapes, which must keep working under load.
+var unlimitedPaths = map[string]bool{"/healthz": true, "/readyz": true, "/metrics": true}
+
+// Limit is a token bucket refilled at RPS tokens per second holding up to Burst tokens.
+// A zero RPS means no limit.
+type Limit struct {
+	RPS   float64
+	Burst int
+}
+
+type RateLimitConfig struct {
+	// PerIP caps all requests of an IP address before authentication, so that requests with
+	// invalid credentials are limited too.
+	PerIP   Limit
+	Default Limit
+	// Routes override Default, keyed by "METHOD /path", e.g. "POST /pullRequest/create".
+	Routes map[string]Limit
+}
+
+// RateLimiter keeps a token bucket per IP address, checked before authentication, and one per
+// caller and route, checked after it.
+type RateLimiter struct {
+	cfg RateLimitConfig
+	now func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*clientBucket
+	lastSweep time.Time
+}
+
+type clientBucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func NewRateLimiter(cfg RateLimitConfig) *RateLimiter {
+	return &RateLimiter{
+		cfg:     cfg,
+		now:     time.Now,
+		buckets: make(map[string]*clientBucket),
+	}
+}
+
+// PerIP answers 429 RATE_LIMITED with Retry-After once the IP address's bucket is empty. Probes
+// and metrics scrapes are never limited. A nil limiter lets every request through.
+func (l *RateLimiter) PerIP(next http.Handler) http.Handler {
+	if l == nil || l.cfg.PerIP.RPS <= 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if unlimitedPaths[r.URL.Path] || l.allow(w, "ip|"+remoteIP(r), l.cfg.PerIP) {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// Middleware answers 429 RATE_LIMITED with Retry-After once the caller's bucket for the route is
+// empty. It must run after authentication, so that only verified credentials get their own
+// bucket. A nil limiter lets every request through.
+func (l *RateLimiter) Middleware(next http.Handler) http.Handler {
+	if l == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := r.Method + " " + r.URL.Path
+		limit, ok := l.cfg.Routes[route]
+		if !ok {
+			limit, route = l.cfg.Default, "*"
+		}
+		if limit.RPS <= 0 || l.allow(w, route+"|"+handler.ClientID(r), limit) {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// allow takes a token from the key's bucket, or writes the 429 response and returns false.
+func (l *RateLimiter) allow(w http.ResponseWriter, key string, limit Limit) bool {
+	wait := l.reserve(key, limit)
+	if wait <= 0 {
+		return true
+	}
+
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	handler.WriteError(w, http.StatusTooManyRequests, "RATE_LIMITED", "rate limit exceeded")
+	return false
+}
+
+// reserve takes a token from the key's bucket and returns zero, or how long until one is available.
+func (l *RateLimiter) reserve(key string, limit Limit) time.Duration {
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) > idleBucketTTL {
+		for k, b := range l.buckets {
+			if now.Sub(b.lastSeen) > idleBucketTTL {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		burst := limit.Burst
+		if burst < 1 {
+			burst = 1
+		}
+		b = &clientBucket{limiter: rate.NewLimiter(rate.Limit(limit.RPS), burst)}
+		l.buckets[key] = b
+	}
+	b.lastSeen = now
+
+	res := b.limiter.ReserveN(now, 1)
+	if delay := res.DelayFrom(now); delay > 0 {
+		res.CancelAt(now)
+		return delay
+	}
+
+	return 0
+}
+
+// remoteIP is the address set by RealIP.
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
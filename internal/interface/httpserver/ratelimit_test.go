@@ -0,0 +1,221 @@
+package httpserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"testing"
+
+	"github.com/Desnn1ch/pr-reviewer-service/internal/app/service"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/infrastructure/metrics"
+	httpserver "github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver"
+	respdto "github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/dto/response"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/handler"
+)
+
+func TestRateLimiter(t *testing.T) {
+	logger := slog.New(slog.DiscardHandler)
+
+	limiter := httpserver.NewRateLimiter(httpserver.RateLimitConfig{
+		Default: httpserver.Limit{RPS: 0.01, Burst: 3},
+		Routes: map[string]httpserver.Limit{
+			"GET /stats/user": {RPS: 0.01, Burst: 1},
+		},
+	})
+
+	router := httpserver.NewRouter(
+		httpserver.Handlers{
+			Team:    handler.NewTeamHandler(nil, logger),
+			User:    handler.NewUserHandler(nil, logger),
+			PR:      handler.NewPRHandler(nil, logger),
+			Stats:   handler.NewStatsHandler(nil, logger),
+			APIKeys: handler.NewAPIKeyHandler(nil, logger),
+			Health:  handler.NewHealthHandler(),
+		},
+		httpserver.Middlewares{
+			RateLimit:      limiter,
+			TrustedProxies: []netip.Prefix{netip.MustParsePrefix("192.0.2.0/24")},
+		},
+		metrics.New(),
+		logger,
+	)
+
+	do := func(url, ip, auth string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		req.Header.Set("X-Real-IP", ip)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := do("/stats/user?user_id=bad", "10.0.0.1", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("first request: got %d, want it to reach the handler", w.Code)
+	}
+
+	w := do("/stats/user?user_id=bad", "10.0.0.1", "")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("second request: got %d, want 429", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "100" {
+		t.Errorf("Retry-After: got %q, want %q", got, "100")
+	}
+	var body respdto.Error
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("decode error body: %v", err)
+	}
+	if body.Error.Code != "RATE_LIMITED" {
+		t.Errorf("code: got %q, want RATE_LIMITED", body.Error.Code)
+	}
+
+	if w := do("/stats/user?user_id=bad", "10.0.0.2", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("other IP: got %d, want its own bucket", w.Code)
+	}
+	if w := do("/stats/user?user_id=bad", "10.0.0.1", "Bearer made-up"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("unverified credential: got %d, want the IP's bucket", w.Code)
+	}
+
+	for i := 0; i < 3; i++ {
+		if w := do("/stats/team?team_name=", "10.0.0.1", ""); w.Code == http.StatusTooManyRequests {
+			t.Fatalf("default limit request %d: got 429 within burst", i+1)
+		}
+	}
+	if w := do("/stats/team?team_name=", "10.0.0.1", ""); w.Code != http.StatusTooManyRequests {
+		t.Errorf("default limit: got %d, want 429 after burst", w.Code)
+	}
+
+	for i := 0; i < 5; i++ {
+		if w := do("/healthz", "10.0.0.1", ""); w.Code != http.StatusOK {
+			t.Fatalf("unlimited route: got %d", w.Code)
+		}
+	}
+}
+
+func TestRateLimiter_WithAuth(t *testing.T) {
+	logger := slog.New(slog.DiscardHandler)
+	keySvc := service.NewAPIKeyService(&memAPIKeyRepo{}, common.StandardClock{}, "")
+
+	_, secret, err := keySvc.Create(context.Background(), "dashboard", []entity.Scope{entity.ScopeStatsRead})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	limiter := httpserver.NewRateLimiter(httpserver.RateLimitConfig{
+		PerIP:   httpserver.Limit{RPS: 0.01, Burst: 3},
+		Default: httpserver.Limit{RPS: 0.01, Burst: 1},
+	})
+
+	router := httpserver.NewRouter(
+		httpserver.Handlers{
+			Team:    handler.NewTeamHandler(nil, logger),
+			User:    handler.NewUserHandler(nil, logger),
+			PR:      handler.NewPRHandler(nil, logger),
+			Stats:   handler.NewStatsHandler(nil, logger),
+			APIKeys: handler.NewAPIKeyHandler(keySvc, logger),
+			Health:  handler.NewHealthHandler(),
+		},
+		httpserver.Middlewares{
+			Auth:           handler.NewAuthMiddleware(service.NewAuthService(keySvc, nil, nil), logger),
+			RateLimit:      limiter,
+			TrustedProxies: []netip.Prefix{netip.MustParsePrefix("192.0.2.0/24")},
+		},
+		metrics.New(),
+		logger,
+	)
+
+	do := func(url, ip, token string) int {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		req.Header.Set("X-Real-IP", ip)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	for i := 0; i < 3; i++ {
+		if code := do("/stats/user?user_id=bad", "10.0.0.1", "made-up-"+strconv.Itoa(i)); code != http.StatusUnauthorized {
+			t.Fatalf("invalid token %d: got %d, want 401 within the IP's burst", i+1, code)
+		}
+	}
+	if code := do("/stats/user?user_id=bad", "10.0.0.1", "made-up-3"); code != http.StatusTooManyRequests {
+		t.Errorf("fresh invalid token: got %d, want 429 before authentication", code)
+	}
+
+	for _, path := range []string{"/healthz", "/readyz", "/metrics"} {
+		if code := do(path, "10.0.0.1", ""); code != http.StatusOK {
+			t.Errorf("%s: got %d, want it exempt from limits", path, code)
+		}
+	}
+
+	if code := do("/stats/user?user_id=bad", "10.0.0.2", secret); code != http.StatusBadRequest {
+		t.Fatalf("valid key: got %d, want it to reach the handler", code)
+	}
+	if code := do("/stats/user?user_id=bad", "10.0.0.3", secret); code != http.StatusTooManyRequests {
+		t.Errorf("valid key from another IP: got %d, want the key's bucket to be shared", code)
+	}
+}
+
+func TestRateLimiter_ForwardedFor(t *testing.T) {
+	logger := slog.New(slog.DiscardHandler)
+
+	newRouter := func(trusted ...netip.Prefix) http.Handler {
+		return httpserver.NewRouter(
+			httpserver.Handlers{
+				Team:    handler.NewTeamHandler(nil, logger),
+				User:    handler.NewUserHandler(nil, logger),
+				PR:      handler.NewPRHandler(nil, logger),
+				Stats:   handler.NewStatsHandler(nil, logger),
+				APIKeys: handler.NewAPIKeyHandler(nil, logger),
+				Health:  handler.NewHealthHandler(),
+			},
+			httpserver.Middlewares{
+				RateLimit:      httpserver.NewRateLimiter(httpserver.RateLimitConfig{PerIP: httpserver.Limit{RPS: 0.01, Burst: 1}}),
+				TrustedProxies: trusted,
+			},
+			metrics.New(),
+			logger,
+		)
+	}
+
+	do := func(router http.Handler, header, value string) int {
+		req := httptest.NewRequest(http.MethodGet, "/stats/user?user_id=bad", nil)
+		req.Header.Set(header, value)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	t.Run("untrusted peer", func(t *testing.T) {
+		router := newRouter()
+		if code := do(router, "X-Forwarded-For", "10.0.0.1"); code != http.StatusBadRequest {
+			t.Fatalf("first request: got %d, want it to reach the handler", code)
+		}
+		if code := do(router, "X-Forwarded-For", "10.0.0.2"); code != http.StatusTooManyRequests {
+			t.Errorf("spoofed X-Forwarded-For: got %d, want the peer's bucket", code)
+		}
+		if code := do(router, "X-Real-IP", "10.0.0.3"); code != http.StatusTooManyRequests {
+			t.Errorf("spoofed X-Real-IP: got %d, want the peer's bucket", code)
+		}
+	})
+
+	t.Run("trusted proxy", func(t *testing.T) {
+		router := newRouter(netip.MustParsePrefix("192.0.2.0/24"), netip.MustParsePrefix("172.16.0.0/12"))
+		if code := do(router, "X-Forwarded-For", "10.0.0.9, 10.0.0.1, 172.16.0.5"); code != http.StatusBadRequest {
+			t.Fatalf("first request: got %d, want it to reach the handler", code)
+		}
+		if code := do(router, "X-Forwarded-For", "10.0.0.8, 10.0.0.1"); code != http.StatusTooManyRequests {
+			t.Errorf("client-added hop: got %d, want the bucket of the address the proxy saw", code)
+		}
+		if code := do(router, "X-Forwarded-For", "10.0.0.2"); code != http.StatusBadRequest {
+			t.Errorf("other client: got %d, want its own bucket", code)
+		}
+	})
+}
@@ -1,54 +1,122 @@
 package httpserver
 
 import (
+	"log/slog"
 	"net/http"
+	"net/netip"
 
+	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/entity"
 	"github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/handler"
 	"github.com/go-chi/chi/v5"
 )
 
-func NewRouter(
-	team *handler.TeamHandler,
-	user *handler.UserHandler,
-	pr *handler.PRHandler,
-	st *handler.StatsHandler,
-) http.Handler {
+// Handlers are the API handlers served by the router.
+type Handlers struct {
+	Team    *handler.TeamHandler
+	User    *handler.UserHandler
+	PR      *handler.PRHandler
+	Stats   *handler.StatsHandler
+	APIKeys *handler.APIKeyHandler
+	Health  *handler.HealthHandler
+}
+
+// Middlewares guard the API routes; a nil one lets every request through.
+type Middlewares struct {
+	Auth        *handler.AuthMiddleware
+	Idempotency *handler.IdempotencyMiddleware
+	RateLimit   *RateLimiter
+	// TrustedProxies may set the client address in X-Forwarded-For or X-Real-IP; with none
+	// the connection's address is used.
+	TrustedProxies []netip.Prefix
+}
+
+func NewRouter(h Handlers, mw Middlewares, m Metrics, logger *slog.Logger) http.Handler {
 	r := chi.NewRouter()
 
-	UseMiddlewares(r)
+	UseMiddlewares(r, logger, mw)
+	r.Use(ObserveRequests(m))
 
-	registerTeamRoutes(r, team)
-	registerUserRoutes(r, user)
-	registerPRRoutes(r, pr)
-	registerStatsRoutes(r, st)
+	r.Method(http.MethodGet, "/metrics", m.Handler())
+	r.Get("/healthz", h.Health.Liveness)
+	r.Get("/readyz", h.Health.Readiness)
+
+	registerTeamRoutes(r, h.Team, mw)
+	registerUserRoutes(r, h.User, mw)
+	registerPRRoutes(r, h.PR, mw)
+	registerStatsRoutes(r, h.Stats, mw)
+	registerAPIKeyRoutes(r, h.APIKeys, mw)
 
 	return r
 }
 
-func registerTeamRoutes(r chi.Router, h *handler.TeamHandler) {
+func registerTeamRoutes(r chi.Router, h *handler.TeamHandler, mw Middlewares) {
 	r.Route("/team", func(r chi.Router) {
-		r.Post("/add", h.Add)
-		r.Get("/get", h.Get)
+		read := r.With(mw.Auth.Require(entity.ScopeTeamsRead), mw.RateLimit.Middleware)
+		write := r.With(mw.Auth.Require(entity.ScopeTeamsWrite), mw.RateLimit.Middleware, mw.Idempotency.Handle)
+
+		write.Post("/add", h.Add)
+		read.Get("/get", h.Get)
+		write.Post("/codeOwners/set", h.SetCodeOwners)
+		read.Get("/codeOwners/get", h.GetCodeOwners)
+		write.Post("/rules/set", h.SetRules)
+		read.Get("/rules/get", h.GetRules)
+		write.Post("/settings/set", h.SetSettings)
+		read.Get("/settings/get", h.GetSettings)
+		write.Post("/roles/set", h.SetRole)
+		read.Get("/roles/get", h.GetRoles)
 	})
 }
 
-func registerUserRoutes(r chi.Router, h *handler.UserHandler) {
+func registerUserRoutes(r chi.Router, h *handler.UserHandler, mw Middlewares) {
 	r.Route("/users", func(r chi.Router) {
-		r.Post("/setIsActive", h.SetIsActive)
-		r.Get("/getReview", h.GetReview)
+		read := r.With(mw.Auth.Require(entity.ScopeUsersRead), mw.RateLimit.Middleware)
+		write := r.With(mw.Auth.Require(entity.ScopeUsersWrite), mw.RateLimit.Middleware, mw.Idempotency.Handle)
+
+		write.Post("/setIsActive", h.SetIsActive)
+		write.Post("/setSkills", h.SetSkills)
+		write.Post("/setWorkingHours", h.SetWorkingHours)
+		write.Post("/setMaxOpenReviews", h.SetMaxOpenReviews)
+		read.Get("/getReview", h.GetReview)
+		write.Post("/availability/add", h.AddUnavailability)
+		write.Post("/availability/update", h.UpdateUnavailability)
+		write.Post("/availability/delete", h.DeleteUnavailability)
+		read.Get("/availability/list", h.ListUnavailability)
 	})
 }
 
-func registerPRRoutes(r chi.Router, h *handler.PRHandler) {
+func registerPRRoutes(r chi.Router, h *handler.PRHandler, mw Middlewares) {
 	r.Route("/pullRequest", func(r chi.Router) {
-		r.Post("/create", h.Create)
-		r.Post("/merge", h.Merge)
-		r.Post("/reassign", h.Reassign)
+		read := r.With(mw.Auth.Require(entity.ScopePRsRead), mw.RateLimit.Middleware)
+		write := r.With(mw.Auth.Require(entity.ScopePRsWrite), mw.RateLimit.Middleware, mw.Idempotency.Handle)
+
+		write.Post("/create", h.Create)
+		write.Post("/merge", h.Merge)
+		write.Post("/reassign", h.Reassign)
+		read.Get("/assignmentExplain", h.AssignmentExplain)
+		read.Get("/overdue", h.Overdue)
+		read.Get("/events", h.Events)
+		write.Post("/review", h.Review)
 	})
 }
 
-func registerStatsRoutes(r chi.Router, h *handler.StatsHandler) {
+func registerStatsRoutes(r chi.Router, h *handler.StatsHandler, mw Middlewares) {
 	r.Route("/stats", func(r chi.Router) {
+		r.Use(mw.Auth.Require(entity.ScopeStatsRead), mw.RateLimit.Middleware)
+
 		r.Get("/reviewers", h.GetReviewerStats)
+		r.Get("/user", h.GetUserStats)
+		r.Get("/team", h.GetTeamStats)
+		r.Get("/fairness", h.GetFairness)
+	})
+}
+
+func registerAPIKeyRoutes(r chi.Router, h *handler.APIKeyHandler, mw Middlewares) {
+	r.Route("/admin/apiKeys", func(r chi.Router) {
+		r.Use(mw.Auth.Require(entity.ScopeAdmin), mw.RateLimit.Middleware)
+
+		// The create response holds the secret, so it is never stored for replay.
+		r.Post("/create", h.Create)
+		r.Get("/list", h.List)
+		r.With(mw.Idempotency.Handle).Post("/revoke", h.Revoke)
 	})
 }
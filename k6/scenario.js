@@ -7,7 +7,16 @@ export const options = {
   duration: '15s',
 }
 
-const BASE_URL = 'http://localhost:8080'
+const BASE_URL = __ENV.BASE_URL || 'http://localhost:8080'
+// ADMIN_API_KEY matches the default of docker-compose.yml.
+const ADMIN_API_KEY = __ENV.ADMIN_API_KEY || 'dev-admin-key'
+
+function headers(key) {
+  return {
+    'Content-Type': 'application/json',
+    Authorization: `Bearer ${key}`,
+  }
+}
 
 const users = new SharedArray('users', () => [
   { id: '11111111-1111-1111-1111-111111111111', username: 'u1' },
@@ -35,13 +44,29 @@ export function setup() {
   })
 
   http.post(`${BASE_URL}/team/add`, payload, {
-    headers: { 'Content-Type': 'application/json' },
+    headers: headers(ADMIN_API_KEY),
   })
 
-  return { teamName: 'backend' }
+  // Rate limits are per caller, so every VU gets its own key instead of sharing the admin one.
+  const keys = []
+  for (let i = 0; i < options.vus; i++) {
+    const res = http.post(`${BASE_URL}/admin/apiKeys/create`, JSON.stringify({
+      name: `k6-vu-${i + 1}`,
+      scopes: ['prs:write', 'users:read'],
+    }), {
+      headers: headers(ADMIN_API_KEY),
+    })
+    if (res.status !== 201) {
+      throw new Error(`create API key: ${res.status} ${res.body}`)
+    }
+    keys.push(res.json('secret'))
+  }
+
+  return { teamName: 'backend', keys }
 }
 
 export default function (data) {
+  const key = data.keys[(__VU - 1) % data.keys.length]
   const author = users[0]
   const prId = generateSequentialUid(prCounter++)
 
@@ -52,7 +77,7 @@ export default function (data) {
   })
 
   const resCreate = http.post(`${BASE_URL}/pullRequest/create`, createPayload, {
-    headers: { 'Content-Type': 'application/json' },
+    headers: headers(key),
   })
 
   check(resCreate, {
@@ -61,7 +86,8 @@ export default function (data) {
 
   const reviewer = users[1]
   const resReview = http.get(
-      `${BASE_URL}/users/getReview?user_id=${reviewer.id}`
+      `${BASE_URL}/users/getReview?user_id=${reviewer.id}`,
+      { headers: headers(key) },
   )
 
   check(resReview, {
@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE pull_requests
+    ADD COLUMN changed_files TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE TABLE code_owner_rules (
+                        team_name   TEXT NOT NULL REFERENCES teams(name) ON DELETE CASCADE,
+                        position    INT NOT NULL,
+                        pattern     TEXT NOT NULL,
+                        owners      UUID[] NOT NULL DEFAULT '{}',
+                        PRIMARY KEY (team_name, position)
+);
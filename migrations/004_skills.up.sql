@@ -0,0 +1,6 @@
+-- +goose Up
+ALTER TABLE users
+    ADD COLUMN skills TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE pull_requests
+    ADD COLUMN required_tags TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE user_unavailability (
+                        id          UUID PRIMARY KEY,
+                        user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                        starts_at   TIMESTAMPTZ NOT NULL,
+                        ends_at     TIMESTAMPTZ NOT NULL,
+                        reason      TEXT NOT NULL DEFAULT '',
+                        handled_at  TIMESTAMPTZ,
+                        CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_user_unavailability_user_id ON user_unavailability (user_id, starts_at);
+CREATE INDEX idx_user_unavailability_unhandled ON user_unavailability (starts_at) WHERE handled_at IS NULL;
+
+CREATE TABLE team_settings (
+                        team_name          TEXT PRIMARY KEY REFERENCES teams(name) ON DELETE CASCADE,
+                        reassign_on_leave  BOOLEAN NOT NULL DEFAULT FALSE
+);
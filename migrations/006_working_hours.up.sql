@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE users
+    ADD COLUMN timezone          TEXT,
+    ADD COLUMN work_start_minute INT,
+    ADD COLUMN work_end_minute   INT,
+    ADD COLUMN work_days         INT[];
+
+ALTER TABLE team_settings
+    ADD COLUMN prefer_working_hours BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,6 @@
+-- +goose Up
+ALTER TABLE users
+    ADD COLUMN max_open_reviews INT CHECK (max_open_reviews >= 0);
+
+ALTER TABLE team_settings
+    ADD COLUMN capacity_policy TEXT NOT NULL DEFAULT 'strict';
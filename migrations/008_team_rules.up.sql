@@ -0,0 +1,12 @@
+-- +goose Up
+ALTER TABLE users
+    ADD COLUMN is_senior BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE team_rules (
+                        team_name   TEXT NOT NULL REFERENCES teams(name) ON DELETE CASCADE,
+                        position    INT NOT NULL,
+                        kind        TEXT NOT NULL,
+                        author_id   UUID REFERENCES users(id) ON DELETE CASCADE,
+                        reviewers   UUID[] NOT NULL DEFAULT '{}',
+                        PRIMARY KEY (team_name, position)
+);
@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE assignment_decisions (
+                        id               UUID PRIMARY KEY,
+                        pr_id            UUID NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+                        kind             TEXT NOT NULL,
+                        strategy         TEXT NOT NULL,
+                        replaced_user_id UUID,
+                        pool             UUID[] NOT NULL DEFAULT '{}',
+                        excluded         JSONB NOT NULL DEFAULT '[]',
+                        chosen           JSONB NOT NULL DEFAULT '[]',
+                        created_at       TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_assignment_decisions_pr ON assignment_decisions (pr_id, created_at);
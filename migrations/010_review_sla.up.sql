@@ -0,0 +1,6 @@
+-- +goose Up
+ALTER TABLE pr_reviewers
+    ADD COLUMN assigned_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+ALTER TABLE team_settings
+    ADD COLUMN review_sla_seconds BIGINT NOT NULL DEFAULT 0 CHECK (review_sla_seconds >= 0);
@@ -0,0 +1,17 @@
+-- +goose Up
+ALTER TABLE team_settings
+    ADD COLUMN escalate_after_seconds BIGINT NOT NULL DEFAULT 0 CHECK (escalate_after_seconds >= 0),
+    ADD COLUMN escalation_policy      TEXT   NOT NULL DEFAULT 'reassign',
+    ADD COLUMN lead_user_id           UUID REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE TABLE pr_events (
+                        id              UUID PRIMARY KEY,
+                        pr_id           UUID NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+                        kind            TEXT NOT NULL,
+                        reviewer_id     UUID,
+                        new_reviewer_id UUID,
+                        detail          TEXT NOT NULL DEFAULT '',
+                        created_at      TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_pr_events_pr ON pr_events (pr_id, created_at);
@@ -0,0 +1,3 @@
+-- +goose Up
+ALTER TABLE users
+    ADD COLUMN email TEXT;
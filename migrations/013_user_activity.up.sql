@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE user_activity_changes (
+                        id         BIGSERIAL PRIMARY KEY,
+                        user_id    UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                        is_active  BOOLEAN NOT NULL,
+                        -- joined marks the user becoming a member of the team; is_active is their state then.
+                        joined     BOOLEAN NOT NULL DEFAULT false,
+                        changed_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_user_activity_changes_user ON user_activity_changes (user_id, changed_at);
@@ -0,0 +1,10 @@
+-- +goose Up
+CREATE TABLE api_keys (
+                        id         UUID PRIMARY KEY,
+                        name       TEXT NOT NULL,
+                        prefix     TEXT NOT NULL,
+                        key_hash   TEXT NOT NULL UNIQUE,
+                        scopes     TEXT[] NOT NULL DEFAULT '{}',
+                        created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                        revoked_at TIMESTAMPTZ
+);
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE team_roles (
+                        team_name TEXT NOT NULL REFERENCES teams(name) ON DELETE CASCADE,
+                        user_id   UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                        role      TEXT NOT NULL CHECK (role IN ('lead', 'admin')),
+                        PRIMARY KEY (team_name, user_id)
+);
+
+INSERT INTO team_roles (team_name, user_id, role)
+SELECT team_name, lead_user_id, 'lead'
+FROM team_settings
+WHERE lead_user_id IS NOT NULL;
+
+-- Team leads live in team_roles from now on; escalation picks them from there.
+ALTER TABLE team_settings DROP COLUMN lead_user_id;
@@ -0,0 +1,2 @@
+-- +goose Up
+ALTER TABLE pull_requests ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
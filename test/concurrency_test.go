@@ -0,0 +1,150 @@
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+
+	dbinfra "github.com/Desnn1ch/pr-reviewer-service/internal/infrastructure/persistence/db"
+	req "github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/dto/request"
+	resp "github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/dto/response"
+)
+
+// TestE2E_ConcurrentReassign replaces both reviewers of a PR at the same time. Without row locks
+// each reassign would rewrite the reviewer list it read, dropping the other's replacement.
+func TestE2E_ConcurrentReassign(t *testing.T) {
+	authorID := addConcurrencyTeam(t, "concurrent-reassign", 8)
+	prs := dbinfra.NewRepositories(db).PRs
+
+	for round := 0; round < 10; round++ {
+		var created resp.CreatePR
+		testPost(t, "/pullRequest/create", req.CreatePR{
+			PullRequestID:   uuid.New().String(),
+			PullRequestName: fmt.Sprintf("Round %d", round),
+			AuthorID:        authorID,
+		}, http.StatusCreated, &created)
+
+		old := created.PR.AssignedReviewers
+		if len(old) != 2 {
+			t.Fatalf("round %d: expected 2 reviewers, got %d", round, len(old))
+		}
+
+		statuses := make([]int, len(old))
+		var wg sync.WaitGroup
+		for i, reviewer := range old {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				statuses[i] = postStatus(req.ReassignReviewer{
+					PullRequestID: created.PR.PullRequestID,
+					OldUserID:     reviewer,
+				}, "/pullRequest/reassign", "")
+			}()
+		}
+		wg.Wait()
+
+		for i, st := range statuses {
+			if st != http.StatusOK {
+				t.Fatalf("round %d: reassign of %s: got %d want %d", round, old[i], st, http.StatusOK)
+			}
+		}
+
+		pr, err := prs.GetByID(context.Background(), uuid.MustParse(created.PR.PullRequestID))
+		if err != nil {
+			t.Fatalf("round %d: load PR: %v", round, err)
+		}
+		if len(pr.Reviewers) != 2 {
+			t.Fatalf("round %d: expected 2 reviewers, got %v", round, pr.Reviewers)
+		}
+		for _, r := range old {
+			if pr.HasReviewer(uuid.MustParse(r)) {
+				t.Fatalf("round %d: replaced reviewer %s is still assigned: %v", round, r, pr.Reviewers)
+			}
+		}
+		if pr.Version != 3 {
+			t.Fatalf("round %d: expected version 3 after two reassigns, got %d", round, pr.Version)
+		}
+	}
+}
+
+// TestE2E_ConcurrentMergeIfMatch merges a PR from several clients holding the same ETag; only the
+// first merge may succeed, the rest see a changed version.
+func TestE2E_ConcurrentMergeIfMatch(t *testing.T) {
+	authorID := addConcurrencyTeam(t, "concurrent-merge", 3)
+
+	var created resp.CreatePR
+	testPost(t, "/pullRequest/create", req.CreatePR{
+		PullRequestID:   uuid.New().String(),
+		PullRequestName: "Merge race",
+		AuthorID:        authorID,
+	}, http.StatusCreated, &created)
+
+	etag := strconv.Quote(strconv.FormatInt(created.PR.Version, 10))
+
+	const clients = 5
+	statuses := make([]int, clients)
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses[i] = postStatus(req.MergePR{PullRequestID: created.PR.PullRequestID}, "/pullRequest/merge", etag)
+		}()
+	}
+	wg.Wait()
+
+	counts := map[int]int{}
+	for _, st := range statuses {
+		counts[st]++
+	}
+	if counts[http.StatusOK] != 1 || counts[http.StatusPreconditionFailed] != clients-1 {
+		t.Fatalf("expected one 200 and %d 412, got %v", clients-1, counts)
+	}
+}
+
+// addConcurrencyTeam creates a team of an author and the given number of reviewers and returns the author.
+func addConcurrencyTeam(t *testing.T, teamName string, reviewers int) string {
+	t.Helper()
+
+	authorID := uuid.New().String()
+	members := []req.TeamMember{{UserID: authorID, Username: "author", IsActive: true}}
+	for i := range reviewers {
+		members = append(members, req.TeamMember{UserID: uuid.New().String(), Username: fmt.Sprintf("r%d", i), IsActive: true})
+	}
+
+	testPost(t, "/team/add", req.TeamAdd{TeamName: teamName, Members: members}, http.StatusOK, nil)
+
+	return authorID
+}
+
+// postStatus sends a POST from a goroutine other than the test's and returns the status, or 0 on a
+// transport error.
+func postStatus(body any, path, ifMatch string) int {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return 0
+	}
+	r, err := http.NewRequest(http.MethodPost, baseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return 0
+	}
+	r.Header.Set("Content-Type", "application/json")
+	if ifMatch != "" {
+		r.Header.Set("If-Match", ifMatch)
+	}
+
+	res, err := http.DefaultClient.Do(r)
+	if err != nil {
+		return 0
+	}
+	_ = res.Body.Close()
+
+	return res.StatusCode
+}
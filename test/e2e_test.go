@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/Desnn1ch/pr-reviewer-service/internal/app/service"
 	"github.com/Desnn1ch/pr-reviewer-service/internal/domain/common"
+	"github.com/Desnn1ch/pr-reviewer-service/internal/infrastructure/metrics"
 	dbinfra "github.com/Desnn1ch/pr-reviewer-service/internal/infrastructure/persistence/db"
 	httpserver "github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver"
 	req "github.com/Desnn1ch/pr-reviewer-service/internal/interface/httpserver/dto/request"
@@ -82,17 +84,39 @@ func TestMain(m *testing.M) {
 	}
 
 	repos := dbinfra.NewRepositories(db)
-	teamSvc := service.NewTeamService(repos.Teams, repos.Users, repos.Tx)
-	userSvc := service.NewUserService(repos.Users, repos.PRs)
-	prSvc := service.NewPRService(repos.PRs, repos.Users, repos.Tx, common.StandardClock{})
-	stSvc := service.NewStatsService(repos.PRs)
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	prom := metrics.New()
+	prom.RegisterDBStats(db.Stats)
+	teamSvc := service.NewTeamService(repos.Teams, repos.Users, repos.Tx, common.StandardClock{})
+	userSvc := service.NewUserService(repos.Users, repos.PRs, repos.Teams, common.StandardClock{})
+	prSvc := service.NewPRService(repos.PRs, repos.Users, repos.Teams, repos.Tx, common.StandardClock{}, prom, logger)
+	stSvc := service.NewStatsService(repos.PRs, repos.Teams, repos.Users)
+	keySvc := service.NewAPIKeyService(repos.APIKeys, common.StandardClock{}, "")
 
-	teamH := handler.NewTeamHandler(teamSvc)
-	userH := handler.NewUserHandler(userSvc)
-	prH := handler.NewPRHandler(prSvc)
-	statsH := handler.NewStatsHandler(stSvc)
+	teamH := handler.NewTeamHandler(teamSvc, logger)
+	userH := handler.NewUserHandler(userSvc, logger)
+	prH := handler.NewPRHandler(prSvc, logger)
+	statsH := handler.NewStatsHandler(stSvc, logger)
+	keysH := handler.NewAPIKeyHandler(keySvc, logger)
 
-	router := httpserver.NewRouter(teamH, userH, prH, statsH)
+	healthH := handler.NewHealthHandler(
+		handler.HealthCheck{Name: "database", Check: db.Ping},
+		handler.HealthCheck{Name: "migrations", Check: db.CheckMigrations},
+	)
+
+	router := httpserver.NewRouter(
+		httpserver.Handlers{
+			Team:    teamH,
+			User:    userH,
+			PR:      prH,
+			Stats:   statsH,
+			APIKeys: keysH,
+			Health:  healthH,
+		},
+		httpserver.Middlewares{},
+		prom,
+		logger,
+	)
 	httpSrv = httptest.NewServer(router)
 	baseURL = httpSrv.URL
 